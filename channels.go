@@ -1,6 +1,7 @@
 package twitch2go
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
@@ -9,9 +10,16 @@ import (
 
 // GetChannelByOAuth will return a Channel object for the given oauth.  Will return annotated errors.
 func (c *Client) GetChannelByOAuth(oauth string) (*Channel, error) {
+	return c.GetChannelByOAuthContext(context.Background(), oauth)
+}
+
+// GetChannelByOAuthContext is like GetChannelByOAuth but uses the given context for the request.
+func (c *Client) GetChannelByOAuthContext(ctx context.Context, oauth string) (*Channel, error) {
 	url := "/channel"
 	ops := &doOptions{
-		oauth: oauth,
+		oauth:   oauth,
+		scope:   "channel_read",
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, ops)
@@ -29,9 +37,14 @@ func (c *Client) GetChannelByOAuth(oauth string) (*Channel, error) {
 
 // GetChannelByID will return a Channel object for the given channelID.  Will return annotated errors.
 func (c *Client) GetChannelByID(channelID string) (*Channel, error) {
-	url := "/channels" + channelID
+	return c.GetChannelByIDContext(context.Background(), channelID)
+}
+
+// GetChannelByIDContext is like GetChannelByID but uses the given context for the request.
+func (c *Client) GetChannelByIDContext(ctx context.Context, channelID string) (*Channel, error) {
+	url := "/channels/" + channelID
 	// Do the request
-	resp, err := c.do("GET", url, &doOptions{})
+	resp, err := c.do("GET", url, &doOptions{context: ctx})
 	if err != nil {
 		return nil, errors.Annotate(err, "GetChannelByID")
 	}
@@ -46,9 +59,16 @@ func (c *Client) GetChannelByID(channelID string) (*Channel, error) {
 
 // GetChannelEditors returns a list of Users that are editors for the given channel.  Requires users oauth token.
 func (c *Client) GetChannelEditors(channelID string, oauth string) (*[]User, error) {
+	return c.GetChannelEditorsContext(context.Background(), channelID, oauth)
+}
+
+// GetChannelEditorsContext is like GetChannelEditors but uses the given context for the request.
+func (c *Client) GetChannelEditorsContext(ctx context.Context, channelID string, oauth string) (*[]User, error) {
 	url := "/channels/" + channelID + "/editors"
 	ops := &doOptions{
-		oauth: oauth,
+		oauth:   oauth,
+		scope:   "channel_read",
+		context: ctx,
 	}
 	// Do the requst
 	resp, err := c.do("GET", url, ops)
@@ -82,11 +102,12 @@ The function takes four parameters:
 		Direction of sorting.  Valid values are `ASC`, and `DESC` (newest first).  Default is `DESC`.
 */
 func (c *Client) GetChannelFollows(channelID string, cursor string, limit int, direction Direction) (*Followers, error) {
-	if limit <= 0 {
-		limit = 25
-	} else if limit > 100 {
-		limit = 100
-	}
+	return c.GetChannelFollowsContext(context.Background(), channelID, cursor, limit, direction)
+}
+
+// GetChannelFollowsContext is like GetChannelFollows but uses the given context for the request.
+func (c *Client) GetChannelFollowsContext(ctx context.Context, channelID string, cursor string, limit int, direction Direction) (*Followers, error) {
+	limit = pageLimit(limit, 25)
 	url := "/channels/" + channelID + "/follows"
 	ops := &doOptions{
 		params: map[string]string{
@@ -94,6 +115,7 @@ func (c *Client) GetChannelFollows(channelID string, cursor string, limit int, d
 			"limit":     strconv.Itoa(limit),
 			"direction": string(direction),
 		},
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, ops)
@@ -127,11 +149,12 @@ Function takes five parameters:
 		Sorting direction.  Valid values are `ASC` and `DESC`.  Default `ASC` (oldest first)
 */
 func (c *Client) GetChannelSubscribers(channelID string, oauth string, limit int, offset int, direction Direction) (*Subscribers, error) {
-	if limit <= 0 {
-		limit = 25
-	} else if limit > 100 {
-		limit = 100
-	}
+	return c.GetChannelSubscribersContext(context.Background(), channelID, oauth, limit, offset, direction)
+}
+
+// GetChannelSubscribersContext is like GetChannelSubscribers but uses the given context for the request.
+func (c *Client) GetChannelSubscribersContext(ctx context.Context, channelID string, oauth string, limit int, offset int, direction Direction) (*Subscribers, error) {
+	limit = pageLimit(limit, 25)
 	url := "/channels/" + channelID + "/subscriptions"
 	ops := &doOptions{
 		params: map[string]string{
@@ -139,7 +162,9 @@ func (c *Client) GetChannelSubscribers(channelID string, oauth string, limit int
 			"offset":    strconv.Itoa(offset),
 			"direction": string(direction),
 		},
-		oauth: oauth,
+		oauth:   oauth,
+		scope:   "channel_subscriptions",
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, ops)
@@ -157,9 +182,16 @@ func (c *Client) GetChannelSubscribers(channelID string, oauth string, limit int
 
 // GetChannelSubscriberByUser will return the subscriber information if the user is subscribed to the channel.
 func (c *Client) GetChannelSubscriberByUser(channelID string, userID string, oauth string) (*Subscription, error) {
-	url := "/channels/" + channelID + "/subscribtions/" + userID
+	return c.GetChannelSubscriberByUserContext(context.Background(), channelID, userID, oauth)
+}
+
+// GetChannelSubscriberByUserContext is like GetChannelSubscriberByUser but uses the given context for the request.
+func (c *Client) GetChannelSubscriberByUserContext(ctx context.Context, channelID string, userID string, oauth string) (*Subscription, error) {
+	url := "/channels/" + channelID + "/subscriptions/" + userID
 	ops := &doOptions{
-		oauth: oauth,
+		oauth:   oauth,
+		scope:   "channel_check_subscription",
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, ops)
@@ -199,20 +231,22 @@ Function takes size parameters:
 		Sorting order of returned videos.  Valid values `views` and `time`.  Default is `time` (most recent first).
 */
 func (c *Client) GetChannelVideos(channelID string, limit int, offset int, broadcastType string, language string, sort VideoSort) (*Videos, error) {
+	return c.GetChannelVideosContext(context.Background(), channelID, limit, offset, broadcastType, language, sort)
+}
+
+// GetChannelVideosContext is like GetChannelVideos but uses the given context for the request.
+func (c *Client) GetChannelVideosContext(ctx context.Context, channelID string, limit int, offset int, broadcastType string, language string, sort VideoSort) (*Videos, error) {
 	url := "/channels/" + channelID + "/videos"
-	if limit <= 0 {
-		limit = 10
-	} else if limit > 100 {
-		limit = 100
-	}
+	limit = pageLimit(limit, 10)
 	opts := &doOptions{
 		params: map[string]string{
 			"limit":          strconv.Itoa(limit),
 			"offset":         strconv.Itoa(offset),
-			"boradcast_type": broadcastType,
+			"broadcast_type": broadcastType,
 			"language":       language,
 			"sort":           string(sort),
 		},
+		context: ctx,
 	}
 
 	// Do the request
@@ -228,3 +262,134 @@ func (c *Client) GetChannelVideos(channelID string, limit int, offset int, broad
 	}
 	return videos, nil
 }
+
+// ChannelUpdate holds the channel properties changed by UpdateChannel.  Nil fields are left unchanged.
+type ChannelUpdate struct {
+	// Status is the title of the channel.
+	Status *string `json:"status,omitempty"`
+	// Game is the name of the game being played.
+	Game *string `json:"game,omitempty"`
+	// Delay is the broadcast delay in seconds.  Only partnered channels can set it.
+	Delay *uint `json:"delay,omitempty"`
+	// ChannelFeedEnabled turns the channel feed on or off.
+	ChannelFeedEnabled *bool `json:"channel_feed_enabled,omitempty"`
+}
+
+// String returns a pointer to s, for the optional fields of ChannelUpdate and similar types.
+func String(s string) *string { return &s }
+
+// Bool returns a pointer to b, for the optional fields of ChannelUpdate and similar types.
+func Bool(b bool) *bool { return &b }
+
+// Uint returns a pointer to u, for the optional fields of ChannelUpdate and similar types.
+func Uint(u uint) *uint { return &u }
+
+// UpdateChannel updates the given channel and returns it.  Requires an oauth token with the `channel_editor` scope.
+func (c *Client) UpdateChannel(channelID string, update ChannelUpdate, oauth string) (*Channel, error) {
+	return c.UpdateChannelContext(context.Background(), channelID, update, oauth)
+}
+
+// UpdateChannelContext is like UpdateChannel but uses the given context for the request.
+func (c *Client) UpdateChannelContext(ctx context.Context, channelID string, update ChannelUpdate, oauth string) (*Channel, error) {
+	url := "/channels/" + channelID
+	ops := &doOptions{
+		data: map[string]ChannelUpdate{
+			"channel": update,
+		},
+		oauth:   oauth,
+		scope:   "channel_editor",
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("PUT", url, ops)
+	if err != nil {
+		return nil, errors.Annotate(err, "UpdateChannel")
+	}
+	defer resp.Body.Close()
+	ch := &Channel{}
+	err = json.NewDecoder(resp.Body).Decode(&ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return ch, nil
+}
+
+// ResetStreamKey resets the stream key of the given channel and returns the channel with its new StreamKey.  Requires
+// an oauth token with the `channel_stream` scope.
+func (c *Client) ResetStreamKey(channelID string, oauth string) (*Channel, error) {
+	return c.ResetStreamKeyContext(context.Background(), channelID, oauth)
+}
+
+// ResetStreamKeyContext is like ResetStreamKey but uses the given context for the request.
+func (c *Client) ResetStreamKeyContext(ctx context.Context, channelID string, oauth string) (*Channel, error) {
+	url := "/channels/" + channelID + "/stream_key"
+	ops := &doOptions{
+		oauth:   oauth,
+		scope:   "channel_stream",
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("DELETE", url, ops)
+	if err != nil {
+		return nil, errors.Annotate(err, "ResetStreamKey")
+	}
+	defer resp.Body.Close()
+	ch := &Channel{}
+	err = json.NewDecoder(resp.Body).Decode(&ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return ch, nil
+}
+
+// CommercialLengths are the commercial lengths, in seconds, accepted by StartCommercial.
+var CommercialLengths = []uint{30, 60, 90, 120, 150, 180}
+
+// Commercial is the result of StartCommercial.
+type Commercial struct {
+	Length  uint   `json:"Length"`
+	Message string `json:"Message"`
+	// RetrySeconds is the number of seconds before another commercial can be run.
+	RetrySeconds uint `json:"RetrySeconds"`
+}
+
+// StartCommercial starts a commercial of the given length on the given channel.  length must be one of
+// CommercialLengths, otherwise an error satisfying errors.IsNotValid is returned without making a request.  Requires
+// an oauth token with the `channel_commercial` scope.
+func (c *Client) StartCommercial(channelID string, length uint, oauth string) (*Commercial, error) {
+	return c.StartCommercialContext(context.Background(), channelID, length, oauth)
+}
+
+// StartCommercialContext is like StartCommercial but uses the given context for the request.
+func (c *Client) StartCommercialContext(ctx context.Context, channelID string, length uint, oauth string) (*Commercial, error) {
+	valid := false
+	for _, l := range CommercialLengths {
+		if l == length {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, errors.NotValidf("commercial length %d", length)
+	}
+	url := "/channels/" + channelID + "/commercial"
+	ops := &doOptions{
+		data: map[string]uint{
+			"length": length,
+		},
+		oauth:   oauth,
+		scope:   "channel_commercial",
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("POST", url, ops)
+	if err != nil {
+		return nil, errors.Annotate(err, "StartCommercial")
+	}
+	defer resp.Body.Close()
+	commercial := &Commercial{}
+	err = json.NewDecoder(resp.Body).Decode(&commercial)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return commercial, nil
+}
@@ -2,9 +2,12 @@ package twitch2go
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
+
+	"github.com/juju/errors"
 )
 
 func TestGetChannelByID(t *testing.T) {
@@ -385,3 +388,83 @@ func TestGetChannelVideos(t *testing.T) {
 		t.Errorf("GetChannelVideos(%q, %q, %q, %q, %q, %q):  Expected %#v.  Got %#v.", channelID, limit, offset, broadcastType, language, sort, expected, videos)
 	}
 }
+
+func TestUpdateChannel(t *testing.T) {
+	channelID := "6391593"
+	oauth := "fakeoauth"
+	jsonChannel := `{
+  "mature": false,
+  "status": "Playing cool new game!",
+  "display_name": "Chosenken",
+  "game": "Overwatch",
+  "language": "en",
+  "name": "chosenken",
+  "_id": "6391593",
+  "url": "https://www.twitch.tv/chosenken"
+}`
+	var expected Channel
+	err := json.Unmarshal([]byte(jsonChannel), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: jsonChannel, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	update := ChannelUpdate{Status: String("Playing cool new game!"), Game: String("Overwatch"), ChannelFeedEnabled: Bool(false)}
+	channel, err := client.UpdateChannel(channelID, update, oauth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*channel, expected) {
+		t.Errorf("UpdateChannel(%q): Expected %#v.  Got %#v.", channelID, expected, channel)
+	}
+	req := fakeRT.requests[0]
+	if req.Method != "PUT" || req.URL.Path != "/kraken/channels/6391593" {
+		t.Errorf("Expected PUT /kraken/channels/6391593.  Got %s %s.", req.Method, req.URL.Path)
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected a JSON content type.  Got %q.", ct)
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	expectedBody := `{"channel":{"status":"Playing cool new game!","game":"Overwatch","channel_feed_enabled":false}}`
+	if string(body) != expectedBody {
+		t.Errorf("Expected body %s.  Got %s.", expectedBody, body)
+	}
+}
+
+func TestResetStreamKey(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"_id": "6391593", "name": "chosenken", "stream_key": "live_6391593_newkey"}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	channel, err := client.ResetStreamKey("6391593", "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel.StreamKey != "live_6391593_newkey" {
+		t.Errorf("Expected the new stream key.  Got %q.", channel.StreamKey)
+	}
+	if req := fakeRT.requests[0]; req.Method != "DELETE" || req.URL.Path != "/kraken/channels/6391593/stream_key" || req.Body != nil {
+		t.Errorf("Expected DELETE /kraken/channels/6391593/stream_key without a body.  Got %s %s.", req.Method, req.URL.Path)
+	}
+}
+
+func TestStartCommercial(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"Length": 60, "Message": "", "RetrySeconds": 480}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	commercial, err := client.StartCommercial("6391593", 60, "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Commercial{Length: 60, RetrySeconds: 480}
+	if *commercial != expected {
+		t.Errorf("StartCommercial: Expected %#v.  Got %#v.", expected, *commercial)
+	}
+	body, _ := ioutil.ReadAll(fakeRT.requests[0].Body)
+	if string(body) != `{"length":60}` {
+		t.Errorf("Expected the length in the body.  Got %s.", body)
+	}
+	if _, err := client.StartCommercial("6391593", 45, "fakeoauth"); !errors.IsNotValid(err) {
+		t.Errorf("Expected a not valid error for a 45 second commercial.  Got %v.", err)
+	}
+	if len(fakeRT.requests) != 1 {
+		t.Errorf("Expected no request for an invalid length.  Got %d requests.", len(fakeRT.requests))
+	}
+}
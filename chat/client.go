@@ -0,0 +1,419 @@
+// Package chat is a client for Twitch chat (TMI).  It connects over IRC or WebSocket, joins channels and reports chat
+// messages as typed events built from their IRCv3 tags.
+//
+//	client := chat.NewClient("mybot", oauth)
+//	client.OnPrivateMessage = func(m chat.PrivateMessage) {
+//		fmt.Printf("#%s %s: %s\n", m.Channel, m.User.DisplayName, m.Text)
+//	}
+//	client.Join("chosenken")
+//	err := client.Run(ctx)
+package chat
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultAddress is the Twitch chat server over IRC with TLS.
+	DefaultAddress = "ircs://irc.chat.twitch.tv:6697"
+	// WebSocketAddress is the Twitch chat server over WebSocket, for networks that only allow HTTPS.
+	WebSocketAddress = "wss://irc-ws.chat.twitch.tv:443"
+	// DefaultPingInterval is how long a connection may be idle before the client checks it, if PingInterval is not
+	// set.
+	DefaultPingInterval = time.Minute
+	// DefaultReconnectDelay is the first delay before reconnecting, if ReconnectDelay is not set.
+	DefaultReconnectDelay = time.Second
+	// DefaultMaxReconnectDelay is the longest delay before reconnecting, if MaxReconnectDelay is not set.
+	DefaultMaxReconnectDelay = 30 * time.Second
+)
+
+// ErrLoginFailed is returned by Run when the server rejects the login or oauth token.
+var ErrLoginFailed = errors.New("chat: login authentication failed")
+
+// errReconnect is returned when the server asks the client to reconnect.
+var errReconnect = errors.New("chat: server asked to reconnect")
+
+// Client is a Twitch chat client.  Set the handlers before calling Run; they are called one at a time from the
+// goroutine running Run.  Join and Part can be called at any time.
+type Client struct {
+	// Address is the chat server, an irc://, ircs://, ws:// or wss:// URL.  Default is DefaultAddress.
+	Address string
+	// Login is the login name of the user to chat as.
+	Login string
+	// OAuth is the user's oauth token with the `chat:read` scope, and `chat:edit` to send.  The `oauth:` prefix is
+	// optional.  Without a token the client connects anonymously and can only read.
+	OAuth string
+	// TLSConfig configures the ircs:// and wss:// connections.  nil uses the defaults.
+	TLSConfig *tls.Config
+	// PingInterval is how long the connection may be idle before the client pings the server.  If the server does not
+	// answer within another PingInterval, the client reconnects.  Default is DefaultPingInterval.
+	PingInterval time.Duration
+	// ReconnectDelay and MaxReconnectDelay bound the delay before reconnecting, which doubles with every failed
+	// attempt.  Defaults are DefaultReconnectDelay and DefaultMaxReconnectDelay.
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+	// MessageLimit and ModMessageLimit are the number of messages that may be sent per MessageWindow, to any channel
+	// and to the channels the user moderates.  Defaults are DefaultMessageLimit, DefaultModMessageLimit and
+	// DefaultMessageWindow, the limits of Twitch.
+	MessageLimit    int
+	ModMessageLimit int
+	MessageWindow   time.Duration
+
+	// OnConnect is called every time the client has logged in and joined its channels.
+	OnConnect func()
+	// OnMessage is called with every message received, before the typed handlers.
+	OnMessage        func(m *Message)
+	OnPrivateMessage func(m PrivateMessage)
+	OnUserNotice     func(n UserNotice)
+	OnClearChat      func(c ClearChat)
+	// OnRoomState is called with the complete room state whenever a channel's chat settings are received.
+	OnRoomState func(r RoomState)
+	OnWhisper   func(w Whisper)
+	// OnUserState is called with the client's own user state in a channel, on join and after sending a message.
+	OnUserState func(s UserState)
+
+	mu       sync.Mutex
+	conn     conn
+	channels map[string]bool
+	rooms    map[string]RoomState
+	mods     map[string]bool
+	queue    []outgoing
+	sent     []time.Time
+	wake     chan struct{}
+}
+
+// NewClient returns a Client chatting as the given user.  An empty login connects anonymously, with a random
+// `justinfan` login.
+func NewClient(login string, oauth string) *Client {
+	if login == "" {
+		login = fmt.Sprintf("justinfan%d", 10000+rand.Intn(90000))
+	}
+	return &Client{
+		Login:    strings.ToLower(login),
+		OAuth:    oauth,
+		channels: map[string]bool{},
+		rooms:    map[string]RoomState{},
+		mods:     map[string]bool{},
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// channelName normalizes a channel name to its lowercase login without the leading `#`.
+func channelName(channel string) string {
+	return strings.ToLower(strings.TrimPrefix(channel, "#"))
+}
+
+// Join joins the given channels, by login name.  Channels joined before Run are joined once connected, and all
+// channels are joined again after a reconnect.
+func (c *Client) Join(channels ...string) error {
+	c.mu.Lock()
+	var names []string
+	for _, ch := range channels {
+		ch = channelName(ch)
+		if !c.channels[ch] {
+			c.channels[ch] = true
+			names = append(names, ch)
+		}
+	}
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil || len(names) == 0 {
+		return nil
+	}
+	return errors.Trace(conn.WriteLine(ircList("JOIN", names)))
+}
+
+// Part leaves the given channels.
+func (c *Client) Part(channels ...string) error {
+	c.mu.Lock()
+	var names []string
+	for _, ch := range channels {
+		ch = channelName(ch)
+		if c.channels[ch] {
+			delete(c.channels, ch)
+			delete(c.rooms, ch)
+			delete(c.mods, ch)
+			names = append(names, ch)
+		}
+	}
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil || len(names) == 0 {
+		return nil
+	}
+	return errors.Trace(conn.WriteLine(ircList("PART", names)))
+}
+
+// Channels returns the joined channels, sorted.
+func (c *Client) Channels() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	channels := make([]string, 0, len(c.channels))
+	for ch := range c.channels {
+		channels = append(channels, ch)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// RoomState returns the chat settings of a joined channel, once received.
+func (c *Client) RoomState(channel string) (RoomState, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.rooms[channelName(channel)]
+	return r, ok
+}
+
+// Connected reports whether the client is connected and logged in.
+func (c *Client) Connected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn != nil
+}
+
+// ircList formats a command taking a comma separated list of channels.
+func ircList(command string, channels []string) string {
+	return command + " #" + strings.Join(channels, ",#")
+}
+
+/*
+Run connects to the chat server and handles messages until ctx is done.  When the connection is lost, or the server
+asks for it, Run reconnects and joins the channels again.
+
+Messages sent with Say and Reply are written by Run, within the message limits.  Messages queued while the
+client is disconnected are sent once it is connected again.
+
+Run returns ctx.Err() once ctx is done, or ErrLoginFailed if the server rejects the credentials.
+*/
+func (c *Client) Run(ctx context.Context) error {
+	var delay time.Duration
+	for {
+		welcomed, err := c.connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		switch {
+		case errors.Cause(err) == ErrLoginFailed:
+			return err
+		case errors.Cause(err) == errReconnect:
+			delay = 0
+		case welcomed || delay == 0:
+			delay = c.reconnectDelay()
+		default:
+			delay *= 2
+			if max := c.maxReconnectDelay(); delay > max {
+				delay = max
+			}
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// connect runs a single connection until it fails.  It reports whether the client got logged in.
+func (c *Client) connect(ctx context.Context) (bool, error) {
+	address := c.Address
+	if address == "" {
+		address = DefaultAddress
+	}
+	conn, err := dial(ctx, address, c.TLSConfig)
+	if err != nil {
+		return false, errors.Annotate(err, "chat: connecting")
+	}
+	activity := make(chan struct{}, 1)
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		conn.Close()
+		c.mu.Lock()
+		c.conn = nil
+		c.mu.Unlock()
+	}()
+	go c.watch(ctx, conn, activity, done)
+
+	login := []string{"CAP REQ :twitch.tv/tags twitch.tv/commands twitch.tv/membership"}
+	if c.OAuth != "" {
+		pass := c.OAuth
+		if !strings.HasPrefix(pass, "oauth:") {
+			pass = "oauth:" + pass
+		}
+		login = append(login, "PASS "+pass)
+	}
+	login = append(login, "NICK "+c.Login)
+	for _, line := range login {
+		if err := conn.WriteLine(line); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	welcomed := false
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return welcomed, errors.Trace(err)
+		}
+		select {
+		case activity <- struct{}{}:
+		default:
+		}
+		m, err := ParseMessage(line)
+		if err != nil {
+			// Skip lines that are not IRC messages.
+			continue
+		}
+		if err := c.handle(conn, m, &welcomed, done); err != nil {
+			return welcomed, err
+		}
+	}
+}
+
+// watch pings the server when the connection has been idle for PingInterval, and closes the connection when the
+// server does not answer, or when ctx is done.
+func (c *Client) watch(ctx context.Context, conn conn, activity <-chan struct{}, done <-chan struct{}) {
+	interval := c.PingInterval
+	if interval <= 0 {
+		interval = DefaultPingInterval
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	pinged := false
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			conn.Close()
+			return
+		case <-activity:
+			pinged = false
+		case <-timer.C:
+			if pinged {
+				// The server did not answer the ping, the connection is dead.
+				conn.Close()
+				return
+			}
+			pinged = true
+			conn.WriteLine("PING :tmi.twitch.tv")
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(interval)
+	}
+}
+
+// handle processes a message received on conn.
+func (c *Client) handle(conn conn, m *Message, welcomed *bool, done <-chan struct{}) error {
+	if c.OnMessage != nil {
+		c.OnMessage(m)
+	}
+	switch m.Command {
+	case "PING":
+		return errors.Trace(conn.WriteLine("PONG :" + m.Trailing()))
+	case "RECONNECT":
+		return errReconnect
+	case "001":
+		*welcomed = true
+		go c.send(conn, done)
+		c.mu.Lock()
+		c.conn = conn
+		channels := make([]string, 0, len(c.channels))
+		for ch := range c.channels {
+			channels = append(channels, ch)
+		}
+		c.mu.Unlock()
+		if len(channels) > 0 {
+			sort.Strings(channels)
+			if err := conn.WriteLine(ircList("JOIN", channels)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if c.OnConnect != nil {
+			c.OnConnect()
+		}
+	case "NOTICE":
+		text := m.Trailing()
+		if !*welcomed && (strings.Contains(text, "Login authentication failed") || strings.Contains(text, "Improperly formatted auth")) {
+			return ErrLoginFailed
+		}
+	case "PRIVMSG":
+		if c.OnPrivateMessage != nil {
+			c.OnPrivateMessage(newPrivateMessage(m))
+		}
+	case "USERNOTICE":
+		if c.OnUserNotice != nil {
+			c.OnUserNotice(newUserNotice(m))
+		}
+	case "CLEARCHAT":
+		if c.OnClearChat != nil {
+			c.OnClearChat(newClearChat(m))
+		}
+	case "ROOMSTATE":
+		c.mu.Lock()
+		r := c.rooms[m.Channel()]
+		r.update(m)
+		if c.channels[m.Channel()] {
+			c.rooms[m.Channel()] = r
+		}
+		c.mu.Unlock()
+		if c.OnRoomState != nil {
+			c.OnRoomState(r)
+		}
+	case "USERSTATE":
+		s := newUserState(m, c.Login)
+		c.mu.Lock()
+		if c.channels[s.Channel] {
+			c.mods[s.Channel] = s.Moderator()
+		}
+		c.mu.Unlock()
+		if c.OnUserState != nil {
+			c.OnUserState(s)
+		}
+	case "WHISPER":
+		if c.OnWhisper != nil {
+			c.OnWhisper(newWhisper(m))
+		}
+	}
+	return nil
+}
+
+func (c *Client) reconnectDelay() time.Duration {
+	if c.ReconnectDelay > 0 {
+		return c.ReconnectDelay
+	}
+	return DefaultReconnectDelay
+}
+
+func (c *Client) maxReconnectDelay() time.Duration {
+	if c.MaxReconnectDelay > 0 {
+		return c.MaxReconnectDelay
+	}
+	return DefaultMaxReconnectDelay
+}
+
+// sleep waits for d or until ctx is done, returning ctx.Err() in that case.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,317 @@
+package chat_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/kenXengineering/twitch2go/chat"
+	"github.com/kenXengineering/twitch2go/twitchtest"
+)
+
+const waitTimeout = 5 * time.Second
+
+// runClient runs client until the test ends.
+func runClient(t *testing.T, client *chat.Client) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(waitTimeout):
+			t.Error("Run did not return after the context was canceled.")
+		}
+	})
+}
+
+func waitFor(t *testing.T, srv *twitchtest.IRCServer, prefix string) string {
+	t.Helper()
+	line, err := srv.WaitFor(prefix, waitTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return line
+}
+
+func TestClientLoginAndJoin(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	srv.AddUser("bot", "secret")
+	client := chat.NewClient("Bot", "secret")
+	client.Address = srv.URL()
+	rooms := make(chan chat.RoomState, 2)
+	client.OnRoomState = func(r chat.RoomState) {
+		rooms <- r
+	}
+	client.Join("#Dallas")
+	runClient(t, client)
+
+	waitFor(t, srv, "CAP REQ :twitch.tv/tags twitch.tv/commands twitch.tv/membership")
+	if line := waitFor(t, srv, "NICK"); line != "NICK bot" {
+		t.Errorf("Expected %q.  Got %q.", "NICK bot", line)
+	}
+	if line := waitFor(t, srv, "JOIN"); line != "JOIN #dallas" {
+		t.Errorf("Expected %q.  Got %q.", "JOIN #dallas", line)
+	}
+	select {
+	case r := <-rooms:
+		if r.Channel != "dallas" || r.FollowersOnly {
+			t.Errorf("Unexpected room state %#v.", r)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("No room state received.")
+	}
+	if _, ok := client.RoomState("dallas"); !ok {
+		t.Error("Expected the room state of dallas to be kept.")
+	}
+
+	client.Join("ronni", "dallas")
+	if line := waitFor(t, srv, "JOIN"); line != "JOIN #ronni" {
+		t.Errorf("Expected %q.  Got %q.", "JOIN #ronni", line)
+	}
+	client.Part("dallas")
+	if line := waitFor(t, srv, "PART"); line != "PART #dallas" {
+		t.Errorf("Expected %q.  Got %q.", "PART #dallas", line)
+	}
+	if channels := client.Channels(); len(channels) != 1 || channels[0] != "ronni" {
+		t.Errorf("Expected [ronni].  Got %v.", channels)
+	}
+}
+
+func TestClientLoginFailed(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	srv.AddUser("bot", "secret")
+	client := chat.NewClient("bot", "oauth:wrong")
+	client.Address = srv.URL()
+	ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+	defer cancel()
+	if err := client.Run(ctx); errors.Cause(err) != chat.ErrLoginFailed {
+		t.Errorf("Expected %v.  Got %v.", chat.ErrLoginFailed, err)
+	}
+}
+
+func TestClientEvents(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	client := chat.NewClient("", "")
+	client.Address = srv.URL()
+	messages := make(chan chat.PrivateMessage, 1)
+	notices := make(chan chat.UserNotice, 1)
+	clears := make(chan chat.ClearChat, 1)
+	whispers := make(chan chat.Whisper, 1)
+	client.OnPrivateMessage = func(m chat.PrivateMessage) { messages <- m }
+	client.OnUserNotice = func(n chat.UserNotice) { notices <- n }
+	client.OnClearChat = func(c chat.ClearChat) { clears <- c }
+	client.OnWhisper = func(w chat.Whisper) { whispers <- w }
+	client.Join("dallas")
+	runClient(t, client)
+	waitFor(t, srv, "JOIN")
+
+	srv.Send("@display-name=Ronni;id=1;user-id=1234 :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :hello")
+	srv.Send("@login=ronni;msg-id=raid;msg-param-viewerCount=42 :tmi.twitch.tv USERNOTICE #dallas")
+	srv.Send("@target-user-id=1234 :tmi.twitch.tv CLEARCHAT #dallas :ronni")
+	srv.Send("@message-id=1 :ronni!ronni@ronni.tmi.twitch.tv WHISPER justinfan :psst")
+	select {
+	case m := <-messages:
+		if m.Text != "hello" || m.User.DisplayName != "Ronni" {
+			t.Errorf("Unexpected message %#v.", m)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("No message received.")
+	}
+	select {
+	case n := <-notices:
+		if !n.IsRaid() || n.Viewers() != 42 {
+			t.Errorf("Unexpected notice %#v.", n)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("No notice received.")
+	}
+	select {
+	case c := <-clears:
+		if !c.Ban() {
+			t.Errorf("Expected a ban.  Got %#v.", c)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("No clear chat received.")
+	}
+	select {
+	case w := <-whispers:
+		if w.Text != "psst" {
+			t.Errorf("Unexpected whisper %#v.", w)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("No whisper received.")
+	}
+}
+
+func TestClientPingPong(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	client := chat.NewClient("", "")
+	client.Address = srv.URL()
+	client.PingInterval = 50 * time.Millisecond
+	client.ReconnectDelay = time.Millisecond
+	runClient(t, client)
+	waitFor(t, srv, "NICK")
+
+	// The client answers the server's pings and pings an idle server.
+	srv.Send("PING :tmi.twitch.tv")
+	waitFor(t, srv, "PONG :tmi.twitch.tv")
+	waitFor(t, srv, "PING")
+
+	// A server that stops answering is reconnected to.
+	srv.DropPings(true)
+	waitFor(t, srv, "NICK")
+}
+
+func TestClientReconnect(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	client := chat.NewClient("", "")
+	client.Address = srv.URL()
+	client.ReconnectDelay = time.Millisecond
+	connects := make(chan bool, 3)
+	client.OnConnect = func() { connects <- true }
+	client.Join("dallas")
+	runClient(t, client)
+	waitFor(t, srv, "JOIN #dallas")
+
+	srv.Disconnect()
+	waitFor(t, srv, "JOIN #dallas")
+	srv.Send(":tmi.twitch.tv RECONNECT")
+	waitFor(t, srv, "JOIN #dallas")
+	for i := 0; i < 3; i++ {
+		select {
+		case <-connects:
+		case <-time.After(waitTimeout):
+			t.Fatalf("Expected 3 connects.  Got %d.", i)
+		}
+	}
+}
+
+func TestClientWebSocket(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	client := chat.NewClient("", "")
+	client.Address = srv.WebSocketURL()
+	messages := make(chan chat.PrivateMessage, 1)
+	client.OnPrivateMessage = func(m chat.PrivateMessage) { messages <- m }
+	client.Join("dallas")
+	runClient(t, client)
+	waitFor(t, srv, "JOIN #dallas")
+
+	srv.Send(":ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :over websocket")
+	select {
+	case m := <-messages:
+		if m.Text != "over websocket" {
+			t.Errorf("Unexpected message %#v.", m)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("No message received.")
+	}
+}
+
+func TestClientSayQueuesWithinLimit(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	client := chat.NewClient("bot", "")
+	client.Address = srv.URL()
+	client.MessageLimit = 2
+	client.MessageWindow = 300 * time.Millisecond
+	client.Join("dallas")
+	// Messages said before connecting are sent once connected.
+	start := time.Now()
+	for _, text := range []string{"one", "two", "three"} {
+		if err := client.Say("#dallas", text); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runClient(t, client)
+
+	for _, text := range []string{"one", "two", "three"} {
+		expected := "PRIVMSG #dallas :" + text
+		if line := waitFor(t, srv, "PRIVMSG"); line != expected {
+			t.Errorf("Expected %q.  Got %q.", expected, line)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < client.MessageWindow {
+		t.Errorf("Expected the third message to wait for the window of %v.  Sent after %v.", client.MessageWindow, elapsed)
+	}
+	if n := client.Queued(); n != 0 {
+		t.Errorf("Expected an empty queue.  Got %d messages.", n)
+	}
+	if err := client.Say("dallas", " \r\n"); err == nil {
+		t.Error("Expected an error saying an empty message.")
+	}
+}
+
+func TestClientModeratorLimit(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	srv.AddModerator("ronni", "bot")
+	client := chat.NewClient("bot", "")
+	client.Address = srv.URL()
+	client.MessageLimit = 1
+	client.ModMessageLimit = 3
+	client.MessageWindow = time.Minute
+	states := make(chan chat.UserState, 2)
+	client.OnUserState = func(s chat.UserState) {
+		select {
+		case states <- s:
+		default:
+		}
+	}
+	client.Join("ronni", "dallas")
+	runClient(t, client)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-states:
+		case <-time.After(waitTimeout):
+			t.Fatal("No user state received.")
+		}
+	}
+	if !client.Moderator("ronni") || client.Moderator("dallas") {
+		t.Fatalf("Expected to moderate ronni only.  Got ronni %v, dallas %v.", client.Moderator("ronni"), client.Moderator("dallas"))
+	}
+
+	for _, text := range []string{"one", "two", "three"} {
+		client.Say("ronni", text)
+	}
+	for i := 0; i < 3; i++ {
+		waitFor(t, srv, "PRIVMSG #ronni")
+	}
+	// The user limit is used up, so the message waits in the queue.
+	client.Say("dallas", "four")
+	if line, err := srv.WaitFor("PRIVMSG", 200*time.Millisecond); err == nil {
+		t.Errorf("Expected the message to be held back.  Got %q.", line)
+	}
+	if n := client.Queued(); n != 1 {
+		t.Errorf("Expected 1 queued message.  Got %d.", n)
+	}
+}
+
+func TestClientReply(t *testing.T) {
+	srv := twitchtest.NewIRCServer()
+	defer srv.Close()
+	client := chat.NewClient("bot", "")
+	client.Address = srv.URL()
+	client.OnPrivateMessage = func(m chat.PrivateMessage) {
+		client.Reply(m, "hi "+m.User.DisplayName)
+	}
+	client.Join("dallas")
+	runClient(t, client)
+	waitFor(t, srv, "JOIN")
+
+	srv.Send("@display-name=Ronni;id=b34ccfc7 :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :hello")
+	expected := "@reply-parent-msg-id=b34ccfc7 PRIVMSG #dallas :hi Ronni"
+	if line := waitFor(t, srv, "@reply-parent-msg-id"); line != expected {
+		t.Errorf("Expected %q.  Got %q.", expected, line)
+	}
+}
@@ -0,0 +1,117 @@
+package chat
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/websocket"
+)
+
+// conn is a line based connection to a chat server.  WriteLine and Close may be called concurrently with ReadLine.
+type conn interface {
+	ReadLine() (string, error)
+	WriteLine(line string) error
+	Close() error
+}
+
+// dial connects to the chat server at address, an irc://, ircs://, ws:// or wss:// URL.
+func dial(ctx context.Context, address string, tlsConfig *tls.Config) (conn, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch u.Scheme {
+	case "irc":
+		d := &net.Dialer{}
+		c, err := d.DialContext(ctx, "tcp", u.Host)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newIRCConn(c), nil
+	case "ircs":
+		d := &tls.Dialer{Config: tlsConfig}
+		c, err := d.DialContext(ctx, "tcp", u.Host)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newIRCConn(c), nil
+	case "ws", "wss":
+		config, err := websocket.NewConfig(address, "http://"+u.Host)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		config.TlsConfig = tlsConfig
+		ws, err := config.DialContext(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &wsConn{ws: ws}, nil
+	}
+	return nil, errors.NotValidf("chat address %q", address)
+}
+
+// ircConn is a plain IRC connection over TCP or TLS.
+type ircConn struct {
+	net.Conn
+	r  *bufio.Reader
+	mu sync.Mutex
+}
+
+func newIRCConn(c net.Conn) *ircConn {
+	return &ircConn{Conn: c, r: bufio.NewReader(c)}
+}
+
+func (c *ircConn) ReadLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *ircConn) WriteLine(line string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.Conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+// wsConn is an IRC connection over WebSocket.  Every frame holds one or more IRC lines.
+type wsConn struct {
+	ws      *websocket.Conn
+	pending []string
+	mu      sync.Mutex
+}
+
+func (c *wsConn) ReadLine() (string, error) {
+	for len(c.pending) == 0 {
+		var frame string
+		if err := websocket.Message.Receive(c.ws, &frame); err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(frame, "\n") {
+			if line = strings.TrimRight(line, "\r"); line != "" {
+				c.pending = append(c.pending, line)
+			}
+		}
+	}
+	line := c.pending[0]
+	c.pending = c.pending[1:]
+	return line, nil
+}
+
+func (c *wsConn) WriteLine(line string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return websocket.Message.Send(c.ws, line+"\r\n")
+}
+
+func (c *wsConn) Close() error {
+	return c.ws.Close()
+}
@@ -0,0 +1,324 @@
+package chat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// User is the sender of a chat message, as described by the message tags.
+type User struct {
+	ID          string
+	Login       string
+	DisplayName string
+	// Color is the user's name color like `#FF4500`, empty if never set.
+	Color string
+	// Badges maps badge names to versions, like `subscriber` to `12`.
+	Badges      map[string]string
+	Broadcaster bool
+	Mod         bool
+	VIP         bool
+	Subscriber  bool
+	Turbo       bool
+}
+
+func newUser(m *Message, login string) User {
+	badges := parseBadges(m.Tags["badges"])
+	u := User{
+		ID:          m.Tags["user-id"],
+		Login:       login,
+		DisplayName: m.Tags["display-name"],
+		Color:       m.Tags["color"],
+		Badges:      badges,
+		Broadcaster: badges["broadcaster"] != "",
+		Mod:         m.Tags["mod"] == "1" || badges["moderator"] != "",
+		VIP:         badges["vip"] != "",
+		Subscriber:  m.Tags["subscriber"] == "1" || badges["subscriber"] != "" || badges["founder"] != "",
+		Turbo:       m.Tags["turbo"] == "1" || badges["turbo"] != "",
+	}
+	if u.DisplayName == "" {
+		u.DisplayName = login
+	}
+	return u
+}
+
+// parseBadges parses a badges tag like `broadcaster/1,subscriber/12`.
+func parseBadges(s string) map[string]string {
+	badges := map[string]string{}
+	for _, badge := range strings.Split(s, ",") {
+		if badge == "" {
+			continue
+		}
+		name, version := badge, ""
+		if i := strings.IndexByte(badge, '/'); i >= 0 {
+			name, version = badge[:i], badge[i+1:]
+		}
+		badges[name] = version
+	}
+	return badges
+}
+
+// sentTime returns the time the server sent the message, from the tmi-sent-ts tag.
+func sentTime(m *Message) time.Time {
+	ms, err := strconv.ParseInt(m.Tags["tmi-sent-ts"], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+func intTag(m *Message, key string) int {
+	i, _ := strconv.Atoi(m.Tags[key])
+	return i
+}
+
+// PrivateMessage is a message sent to a channel.
+type PrivateMessage struct {
+	ID      string
+	Channel string
+	RoomID  string
+	User    User
+	Text    string
+	// Action is true for /me messages.  The ACTION markers are removed from Text.
+	Action bool
+	// Bits is the number of bits cheered with the message.
+	Bits int
+	// ReplyParentID is the ID of the message this one replies to, if any.
+	ReplyParentID string
+	Time          time.Time
+	Raw           *Message
+}
+
+func newPrivateMessage(m *Message) PrivateMessage {
+	pm := PrivateMessage{
+		ID:            m.Tags["id"],
+		Channel:       m.Channel(),
+		RoomID:        m.Tags["room-id"],
+		User:          newUser(m, m.Nick()),
+		Text:          m.Trailing(),
+		Bits:          intTag(m, "bits"),
+		ReplyParentID: m.Tags["reply-parent-msg-id"],
+		Time:          sentTime(m),
+		Raw:           m,
+	}
+	if strings.HasPrefix(pm.Text, "\x01ACTION ") && strings.HasSuffix(pm.Text, "\x01") {
+		pm.Action = true
+		pm.Text = pm.Text[len("\x01ACTION ") : len(pm.Text)-1]
+	}
+	return pm
+}
+
+// The UserNotice types reported by Twitch.  Other types, like `announcement`, are passed through as is.
+const (
+	NoticeSub             = "sub"
+	NoticeResub           = "resub"
+	NoticeSubGift         = "subgift"
+	NoticeMysteryGift     = "submysterygift"
+	NoticeGiftPaidUpgrade = "giftpaidupgrade"
+	NoticeRaid            = "raid"
+	NoticeUnraid          = "unraid"
+	NoticeRitual          = "ritual"
+)
+
+// UserNotice is a channel event announced in chat, like a subscription or a raid.
+type UserNotice struct {
+	ID      string
+	Channel string
+	RoomID  string
+	// Type is the kind of event, one of the Notice constants.
+	Type string
+	// User is the user who caused the event: the subscriber, gifter or raider.
+	User User
+	// SystemMessage is the text Twitch shows for the event.
+	SystemMessage string
+	// Text is the message the user added, if any.
+	Text string
+	// Params holds the msg-param tags without their prefix, like `cumulative-months` or `viewerCount`.
+	Params map[string]string
+	Time   time.Time
+	Raw    *Message
+}
+
+func newUserNotice(m *Message) UserNotice {
+	params := map[string]string{}
+	for k, v := range m.Tags {
+		if strings.HasPrefix(k, "msg-param-") {
+			params[strings.TrimPrefix(k, "msg-param-")] = v
+		}
+	}
+	n := UserNotice{
+		ID:            m.Tags["id"],
+		Channel:       m.Channel(),
+		RoomID:        m.Tags["room-id"],
+		Type:          m.Tags["msg-id"],
+		User:          newUser(m, m.Tags["login"]),
+		SystemMessage: m.Tags["system-msg"],
+		Params:        params,
+		Time:          sentTime(m),
+		Raw:           m,
+	}
+	if len(m.Params) > 1 {
+		n.Text = m.Trailing()
+	}
+	return n
+}
+
+// IsSubscription reports whether the notice is a subscription, a resubscription or a gifted subscription.
+func (n UserNotice) IsSubscription() bool {
+	switch n.Type {
+	case NoticeSub, NoticeResub, NoticeSubGift, NoticeMysteryGift, NoticeGiftPaidUpgrade:
+		return true
+	}
+	return false
+}
+
+// IsRaid reports whether the notice is an incoming raid.
+func (n UserNotice) IsRaid() bool {
+	return n.Type == NoticeRaid
+}
+
+// Months returns the total number of months the user has been subscribed, for subscriptions.
+func (n UserNotice) Months() int {
+	months, _ := strconv.Atoi(n.Params["cumulative-months"])
+	return months
+}
+
+// SubPlan returns the subscription plan, `Prime`, `1000`, `2000` or `3000`, for subscriptions.
+func (n UserNotice) SubPlan() string {
+	return n.Params["sub-plan"]
+}
+
+// Recipient returns the login of the user who received a gifted subscription.
+func (n UserNotice) Recipient() string {
+	return n.Params["recipient-user-name"]
+}
+
+// Viewers returns the number of viewers brought along, for raids.
+func (n UserNotice) Viewers() int {
+	viewers, _ := strconv.Atoi(n.Params["viewerCount"])
+	return viewers
+}
+
+// ClearChat is sent when a user is timed out or banned, or when the whole chat is cleared.
+type ClearChat struct {
+	Channel string
+	RoomID  string
+	// TargetLogin and TargetID are the user whose messages were removed.  Both are empty when the whole chat was
+	// cleared.
+	TargetLogin string
+	TargetID    string
+	// Duration is the length of a timeout.  It is 0 for bans and chat clears.
+	Duration time.Duration
+	Time     time.Time
+	Raw      *Message
+}
+
+func newClearChat(m *Message) ClearChat {
+	c := ClearChat{
+		Channel:  m.Channel(),
+		RoomID:   m.Tags["room-id"],
+		TargetID: m.Tags["target-user-id"],
+		Duration: time.Duration(intTag(m, "ban-duration")) * time.Second,
+		Time:     sentTime(m),
+		Raw:      m,
+	}
+	if len(m.Params) > 1 {
+		c.TargetLogin = m.Trailing()
+	}
+	return c
+}
+
+// Ban reports whether the user was banned permanently.
+func (c ClearChat) Ban() bool {
+	return c.TargetLogin != "" && c.Duration == 0
+}
+
+// RoomState holds the chat settings of a channel.  Twitch sends the full state on join and only the changed settings
+// afterwards.  The client keeps track of the full state, so every RoomState it reports is complete.
+type RoomState struct {
+	Channel   string
+	RoomID    string
+	EmoteOnly bool
+	// FollowersOnly restricts chat to followers who have followed for at least FollowersOnlyDuration.
+	FollowersOnly         bool
+	FollowersOnlyDuration time.Duration
+	// R9K rejects messages that are not unique.
+	R9K bool
+	// Slow is the minimum time between two messages of a user.  0 means slow mode is off.
+	Slow     time.Duration
+	SubsOnly bool
+}
+
+// update applies the settings present in m.
+func (r *RoomState) update(m *Message) {
+	r.Channel = m.Channel()
+	if v, ok := m.Tags["room-id"]; ok {
+		r.RoomID = v
+	}
+	if v, ok := m.Tags["emote-only"]; ok {
+		r.EmoteOnly = v == "1"
+	}
+	if v, ok := m.Tags["followers-only"]; ok {
+		// -1 turns followers only mode off, other values are the minimum follow age in minutes.
+		minutes, _ := strconv.Atoi(v)
+		r.FollowersOnly = minutes >= 0
+		r.FollowersOnlyDuration = 0
+		if minutes > 0 {
+			r.FollowersOnlyDuration = time.Duration(minutes) * time.Minute
+		}
+	}
+	if v, ok := m.Tags["r9k"]; ok {
+		r.R9K = v == "1"
+	}
+	if v, ok := m.Tags["slow"]; ok {
+		seconds, _ := strconv.Atoi(v)
+		r.Slow = time.Duration(seconds) * time.Second
+	}
+	if v, ok := m.Tags["subs-only"]; ok {
+		r.SubsOnly = v == "1"
+	}
+}
+
+// Whisper is a private message sent to the client's user.
+type Whisper struct {
+	ID       string
+	ThreadID string
+	From     User
+	// To is the login of the recipient.
+	To   string
+	Text string
+	Raw  *Message
+}
+
+func newWhisper(m *Message) Whisper {
+	return Whisper{
+		ID:       m.Tags["message-id"],
+		ThreadID: m.Tags["thread-id"],
+		From:     newUser(m, m.Nick()),
+		To:       m.Param(0),
+		Text:     m.Trailing(),
+		Raw:      m,
+	}
+}
+
+// UserState describes the client's own user in a channel.  Twitch sends it on join and after every message the client
+// sends.
+type UserState struct {
+	Channel string
+	User    User
+	Raw     *Message
+}
+
+func newUserState(m *Message, login string) UserState {
+	return UserState{
+		Channel: m.Channel(),
+		User:    newUser(m, login),
+		Raw:     m,
+	}
+}
+
+// Moderator reports whether the client's user is a moderator or the broadcaster of the channel, which raises its
+// message limit.
+func (s UserState) Moderator() bool {
+	return s.User.Mod || s.User.Broadcaster
+}
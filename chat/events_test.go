@@ -0,0 +1,105 @@
+package chat
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, line string) *Message {
+	m, err := ParseMessage(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestPrivateMessage(t *testing.T) {
+	m := mustParse(t, "@badge-info=subscriber/14;badges=moderator/1,subscriber/12;bits=100;color=#FF4500;display-name=Ronni;id=b34ccfc7;mod=1;room-id=1337;subscriber=1;tmi-sent-ts=1507246572675;turbo=0;user-id=1234 :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :\x01ACTION cheers cheer100\x01")
+	pm := newPrivateMessage(m)
+	if pm.ID != "b34ccfc7" || pm.Channel != "dallas" || pm.RoomID != "1337" {
+		t.Errorf("Unexpected message identity %#v.", pm)
+	}
+	if !pm.Action || pm.Text != "cheers cheer100" || pm.Bits != 100 {
+		t.Errorf("Unexpected message text %q, action %v, bits %d.", pm.Text, pm.Action, pm.Bits)
+	}
+	u := pm.User
+	if u.ID != "1234" || u.Login != "ronni" || u.DisplayName != "Ronni" || !u.Mod || !u.Subscriber || u.Broadcaster || u.Turbo {
+		t.Errorf("Unexpected user %#v.", u)
+	}
+	if u.Badges["subscriber"] != "12" {
+		t.Errorf("Expected subscriber badge %q.  Got %q.", "12", u.Badges["subscriber"])
+	}
+	if expected := time.Unix(1507246572, 675000000); !pm.Time.Equal(expected) {
+		t.Errorf("Expected %v.  Got %v.", expected, pm.Time)
+	}
+}
+
+func TestUserNoticeSubscription(t *testing.T) {
+	m := mustParse(t, `@badges=staff/1,broadcaster/1;display-name=Ronni;id=db25007f;login=ronni;msg-id=resub;msg-param-cumulative-months=6;msg-param-sub-plan=Prime;room-id=1337;system-msg=ronni\shas\ssubscribed\sfor\s6\smonths!;tmi-sent-ts=1507246572675;user-id=1337 :tmi.twitch.tv USERNOTICE #dallas :Great stream -- keep it up!`)
+	n := newUserNotice(m)
+	if !n.IsSubscription() || n.IsRaid() || n.Type != NoticeResub {
+		t.Errorf("Expected a resub.  Got %q.", n.Type)
+	}
+	if n.Months() != 6 || n.SubPlan() != "Prime" {
+		t.Errorf("Expected 6 months of Prime.  Got %d months of %q.", n.Months(), n.SubPlan())
+	}
+	if n.Text != "Great stream -- keep it up!" || n.SystemMessage != "ronni has subscribed for 6 months!" {
+		t.Errorf("Unexpected text %q and system message %q.", n.Text, n.SystemMessage)
+	}
+	if n.User.Login != "ronni" || !n.User.Broadcaster {
+		t.Errorf("Unexpected user %#v.", n.User)
+	}
+}
+
+func TestUserNoticeRaid(t *testing.T) {
+	m := mustParse(t, `@display-name=TestChannel;login=testchannel;msg-id=raid;msg-param-displayName=TestChannel;msg-param-login=testchannel;msg-param-viewerCount=15;room-id=33332222;system-msg=15\sraiders\sfrom\sTestChannel\shave\sjoined\n!;user-id=123456 :tmi.twitch.tv USERNOTICE #othertestchannel`)
+	n := newUserNotice(m)
+	if !n.IsRaid() || n.IsSubscription() || n.Viewers() != 15 {
+		t.Errorf("Expected a raid of 15 viewers.  Got %q with %d viewers.", n.Type, n.Viewers())
+	}
+	if n.Text != "" {
+		t.Errorf("Expected no text.  Got %q.", n.Text)
+	}
+	if n.SystemMessage != "15 raiders from TestChannel have joined\n!" {
+		t.Errorf("Unexpected system message %q.", n.SystemMessage)
+	}
+}
+
+func TestClearChat(t *testing.T) {
+	timeout := newClearChat(mustParse(t, "@ban-duration=350;room-id=12345678;target-user-id=87654321;tmi-sent-ts=1642715756806 :tmi.twitch.tv CLEARCHAT #dallas :ronni"))
+	if timeout.TargetLogin != "ronni" || timeout.TargetID != "87654321" || timeout.Duration != 350*time.Second || timeout.Ban() {
+		t.Errorf("Unexpected timeout %#v.", timeout)
+	}
+	ban := newClearChat(mustParse(t, "@room-id=12345678;target-user-id=87654321 :tmi.twitch.tv CLEARCHAT #dallas :ronni"))
+	if !ban.Ban() {
+		t.Errorf("Expected a ban.  Got %#v.", ban)
+	}
+	clear := newClearChat(mustParse(t, "@room-id=12345678 :tmi.twitch.tv CLEARCHAT #dallas"))
+	if clear.TargetLogin != "" || clear.Ban() {
+		t.Errorf("Expected a chat clear.  Got %#v.", clear)
+	}
+}
+
+func TestRoomStateUpdate(t *testing.T) {
+	var r RoomState
+	r.update(mustParse(t, "@emote-only=0;followers-only=-1;r9k=0;room-id=1337;slow=0;subs-only=0 :tmi.twitch.tv ROOMSTATE #dallas"))
+	r.update(mustParse(t, "@followers-only=10;room-id=1337 :tmi.twitch.tv ROOMSTATE #dallas"))
+	r.update(mustParse(t, "@room-id=1337;slow=30 :tmi.twitch.tv ROOMSTATE #dallas"))
+	expected := RoomState{
+		Channel:               "dallas",
+		RoomID:                "1337",
+		FollowersOnly:         true,
+		FollowersOnlyDuration: 10 * time.Minute,
+		Slow:                  30 * time.Second,
+	}
+	if r != expected {
+		t.Errorf("Expected %#v.  Got %#v.", expected, r)
+	}
+}
+
+func TestWhisper(t *testing.T) {
+	w := newWhisper(mustParse(t, "@badges=;color=;display-name=PetsgomOO;message-id=306;thread-id=12345678_87654321;user-id=87654321 :petsgomoo!petsgomoo@petsgomoo.tmi.twitch.tv WHISPER foo :hello"))
+	if w.From.Login != "petsgomoo" || w.From.ID != "87654321" || w.To != "foo" || w.Text != "hello" || w.ThreadID != "12345678_87654321" {
+		t.Errorf("Unexpected whisper %#v.", w)
+	}
+}
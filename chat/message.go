@@ -0,0 +1,176 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Message is a raw IRC message with its IRCv3 tags.
+type Message struct {
+	// Tags are the IRCv3 tags of the message, unescaped.  Tags without a value map to the empty string.
+	Tags map[string]string
+	// Prefix is the source of the message without the leading colon, like `nick!nick@nick.tmi.twitch.tv`.
+	Prefix  string
+	Command string
+	// Params are the parameters of the message.  The trailing parameter, if any, is the last one.
+	Params []string
+}
+
+// ParseMessage parses a single IRC line.  A trailing CRLF is ignored.
+func ParseMessage(line string) (*Message, error) {
+	raw := line
+	line = strings.TrimRight(line, "\r\n")
+	m := &Message{}
+	if strings.HasPrefix(line, "@") {
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return nil, errors.NotValidf("message %q", raw)
+		}
+		m.Tags = parseTags(line[1:i])
+		line = strings.TrimLeft(line[i+1:], " ")
+	}
+	if strings.HasPrefix(line, ":") {
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return nil, errors.NotValidf("message %q", raw)
+		}
+		m.Prefix = line[1:i]
+		line = strings.TrimLeft(line[i+1:], " ")
+	}
+	if i := strings.IndexByte(line, ' '); i < 0 {
+		m.Command, line = line, ""
+	} else {
+		m.Command, line = line[:i], strings.TrimLeft(line[i+1:], " ")
+	}
+	if m.Command == "" {
+		return nil, errors.NotValidf("message %q", raw)
+	}
+	for line != "" {
+		if strings.HasPrefix(line, ":") {
+			m.Params = append(m.Params, line[1:])
+			break
+		}
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			m.Params = append(m.Params, line)
+			break
+		}
+		m.Params = append(m.Params, line[:i])
+		line = strings.TrimLeft(line[i+1:], " ")
+	}
+	return m, nil
+}
+
+// Nick returns the nick of the message source, the part of Prefix before the `!`.
+func (m *Message) Nick() string {
+	if i := strings.IndexByte(m.Prefix, '!'); i >= 0 {
+		return m.Prefix[:i]
+	}
+	return m.Prefix
+}
+
+// Param returns the i-th parameter, or the empty string if there are not that many.
+func (m *Message) Param(i int) string {
+	if i < len(m.Params) {
+		return m.Params[i]
+	}
+	return ""
+}
+
+// Trailing returns the last parameter, usually the text of the message.
+func (m *Message) Trailing() string {
+	if len(m.Params) == 0 {
+		return ""
+	}
+	return m.Params[len(m.Params)-1]
+}
+
+// Channel returns the channel the message was sent to, without the leading `#`.
+func (m *Message) Channel() string {
+	return strings.TrimPrefix(m.Param(0), "#")
+}
+
+// String formats the message as an IRC line without the trailing CRLF.
+func (m *Message) String() string {
+	var b strings.Builder
+	if len(m.Tags) > 0 {
+		b.WriteByte('@')
+		first := true
+		for k, v := range m.Tags {
+			if !first {
+				b.WriteByte(';')
+			}
+			first = false
+			b.WriteString(k)
+			if v != "" {
+				b.WriteByte('=')
+				b.WriteString(tagEscaper.Replace(v))
+			}
+		}
+		b.WriteByte(' ')
+	}
+	if m.Prefix != "" {
+		b.WriteByte(':')
+		b.WriteString(m.Prefix)
+		b.WriteByte(' ')
+	}
+	b.WriteString(m.Command)
+	for i, p := range m.Params {
+		b.WriteByte(' ')
+		if i == len(m.Params)-1 && (p == "" || strings.ContainsRune(p, ' ') || strings.HasPrefix(p, ":")) {
+			b.WriteByte(':')
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+var tagEscaper = strings.NewReplacer(`\`, `\\`, ";", `\:`, " ", `\s`, "\r", `\r`, "\n", `\n`)
+
+func parseTags(s string) map[string]string {
+	tags := map[string]string{}
+	for _, tag := range strings.Split(s, ";") {
+		if tag == "" {
+			continue
+		}
+		k, v := tag, ""
+		if i := strings.IndexByte(tag, '='); i >= 0 {
+			k, v = tag[:i], unescapeTag(tag[i+1:])
+		}
+		tags[k] = v
+	}
+	return tags
+}
+
+// unescapeTag undoes the IRCv3 tag value escaping.  Unknown escapes drop the backslash, a trailing backslash is
+// dropped.
+func unescapeTag(v string) string {
+	if !strings.Contains(v, `\`) {
+		return v
+	}
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] != '\\' {
+			b.WriteByte(v[i])
+			continue
+		}
+		i++
+		if i == len(v) {
+			break
+		}
+		switch v[i] {
+		case ':':
+			b.WriteByte(';')
+		case 's':
+			b.WriteByte(' ')
+		case 'r':
+			b.WriteByte('\r')
+		case 'n':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(v[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package chat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected *Message
+	}{
+		{
+			line:     "PING :tmi.twitch.tv\r\n",
+			expected: &Message{Command: "PING", Params: []string{"tmi.twitch.tv"}},
+		},
+		{
+			line: ":ronni!ronni@ronni.tmi.twitch.tv JOIN #dallas",
+			expected: &Message{
+				Prefix:  "ronni!ronni@ronni.tmi.twitch.tv",
+				Command: "JOIN",
+				Params:  []string{"#dallas"},
+			},
+		},
+		{
+			line: `@badges=broadcaster/1;color=#0D4200;display-name=Ronni;emotes=;flag;system-msg=Ronni\shas\ssubscribed\:\\o/ :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Kappa  Keepo`,
+			expected: &Message{
+				Tags: map[string]string{
+					"badges":       "broadcaster/1",
+					"color":        "#0D4200",
+					"display-name": "Ronni",
+					"emotes":       "",
+					"flag":         "",
+					"system-msg":   `Ronni has subscribed;\o/`,
+				},
+				Prefix:  "ronni!ronni@ronni.tmi.twitch.tv",
+				Command: "PRIVMSG",
+				Params:  []string{"#dallas", "Kappa  Keepo"},
+			},
+		},
+		{
+			line: ":tmi.twitch.tv CAP * ACK :twitch.tv/tags twitch.tv/commands",
+			expected: &Message{
+				Prefix:  "tmi.twitch.tv",
+				Command: "CAP",
+				Params:  []string{"*", "ACK", "twitch.tv/tags twitch.tv/commands"},
+			},
+		},
+	}
+	for _, test := range tests {
+		m, err := ParseMessage(test.line)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", test.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(m, test.expected) {
+			t.Errorf("Expected %#v.  Got %#v.", test.expected, m)
+		}
+	}
+}
+
+func TestParseMessageInvalid(t *testing.T) {
+	for _, line := range []string{"", "@tags-only", ":prefix-only", "@a=b :prefix"} {
+		if m, err := ParseMessage(line); err == nil {
+			t.Errorf("Expected an error parsing %q.  Got %#v.", line, m)
+		}
+	}
+}
+
+func TestMessageString(t *testing.T) {
+	m := &Message{
+		Tags:    map[string]string{"system-msg": "a b;c"},
+		Prefix:  "tmi.twitch.tv",
+		Command: "USERNOTICE",
+		Params:  []string{"#dallas", "hello there"},
+	}
+	expected := `@system-msg=a\sb\:c :tmi.twitch.tv USERNOTICE #dallas :hello there`
+	if s := m.String(); s != expected {
+		t.Errorf("Expected %q.  Got %q.", expected, s)
+	}
+	parsed, err := ParseMessage(m.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, m) {
+		t.Errorf("Expected %#v.  Got %#v.", m, parsed)
+	}
+}
+
+func TestMessageAccessors(t *testing.T) {
+	m, err := ParseMessage(":ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Nick() != "ronni" || m.Channel() != "dallas" || m.Trailing() != "hi" || m.Param(5) != "" {
+		t.Errorf("Unexpected accessors for %#v: %q %q %q.", m, m.Nick(), m.Channel(), m.Trailing())
+	}
+}
@@ -0,0 +1,167 @@
+package chat
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultMessageLimit is the number of messages a user may send per MessageWindow, if MessageLimit is not set.
+	DefaultMessageLimit = 20
+	// DefaultModMessageLimit is the number of messages a moderator may send per MessageWindow to the channels they
+	// moderate, if ModMessageLimit is not set.
+	DefaultModMessageLimit = 100
+	// DefaultMessageWindow is the window the message limits apply to, if MessageWindow is not set.
+	DefaultMessageWindow = 30 * time.Second
+)
+
+// outgoing is a queued message.
+type outgoing struct {
+	channel string
+	line    string
+}
+
+// Say sends a message to a channel.  The message is queued and sent as soon as the message limits allow, see Run.
+func (c *Client) Say(channel string, text string) error {
+	channel = channelName(channel)
+	text, err := messageText(text)
+	if err != nil {
+		return err
+	}
+	c.enqueue(channel, "PRIVMSG #"+channel+" :"+text)
+	return nil
+}
+
+// Reply sends a message to the channel of m, as a reply to m.  It is queued like Say.
+func (c *Client) Reply(m PrivateMessage, text string) error {
+	text, err := messageText(text)
+	if err != nil {
+		return err
+	}
+	if m.ID == "" {
+		return errors.NotValidf("reply to a message without ID")
+	}
+	c.enqueue(m.Channel, "@reply-parent-msg-id="+tagEscaper.Replace(m.ID)+" PRIVMSG #"+m.Channel+" :"+text)
+	return nil
+}
+
+// Queued returns the number of messages waiting to be sent.
+func (c *Client) Queued() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.queue)
+}
+
+// Moderator reports whether the client's user moderates the given joined channel, according to the last USERSTATE
+// received.
+func (c *Client) Moderator(channel string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mods[channelName(channel)]
+}
+
+// messageText cleans up the text of a message, which must fit on one IRC line.
+func messageText(text string) (string, error) {
+	text = strings.TrimSpace(strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(text))
+	if text == "" {
+		return "", errors.NotValidf("empty message")
+	}
+	return text, nil
+}
+
+func (c *Client) enqueue(channel string, line string) {
+	c.mu.Lock()
+	c.queue = append(c.queue, outgoing{channel: channel, line: line})
+	c.mu.Unlock()
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+/*
+send writes the queued messages to conn, in order, until done is closed or a write fails.
+
+Twitch allows MessageLimit messages per MessageWindow on a connection, or ModMessageLimit when sending to a channel the
+user moderates.  Going over gets the user muted for a while, so send keeps track of the messages sent in the last
+window and waits before sending one that would go over its limit.  Messages are not reordered, one waiting for the
+user limit holds back the ones behind it.
+*/
+func (c *Client) send(conn conn, done <-chan struct{}) {
+	for {
+		c.mu.Lock()
+		if len(c.queue) == 0 {
+			c.mu.Unlock()
+			select {
+			case <-c.wake:
+				continue
+			case <-done:
+				return
+			}
+		}
+		next := c.queue[0]
+		limit := c.messageLimit()
+		if c.mods[next.channel] {
+			limit = c.modMessageLimit()
+		}
+		now := time.Now()
+		if wait := c.reserve(now, limit); wait > 0 {
+			c.mu.Unlock()
+			t := time.NewTimer(wait)
+			select {
+			case <-t.C:
+				continue
+			case <-done:
+				t.Stop()
+				return
+			}
+		}
+		// Dequeue before writing, so that Queued no longer counts a message once it may have reached the server.
+		c.queue = c.queue[1:]
+		c.sent = append(c.sent, now)
+		c.mu.Unlock()
+		if err := conn.WriteLine(next.line); err != nil {
+			// Queue the message again for the next connection.  It stays counted in sent, in case it did go out.
+			c.mu.Lock()
+			c.queue = append([]outgoing{next}, c.queue...)
+			c.mu.Unlock()
+			return
+		}
+	}
+}
+
+// reserve forgets the messages sent before the current window and returns how long to wait before a message with the
+// given limit may be sent.  Must be called with c.mu held.
+func (c *Client) reserve(now time.Time, limit int) time.Duration {
+	window := c.MessageWindow
+	if window <= 0 {
+		window = DefaultMessageWindow
+	}
+	start := now.Add(-window)
+	i := 0
+	for i < len(c.sent) && !c.sent[i].After(start) {
+		i++
+	}
+	c.sent = c.sent[i:]
+	if len(c.sent) < limit {
+		return 0
+	}
+	// Wait until enough messages have left the window.
+	return c.sent[len(c.sent)-limit].Sub(start)
+}
+
+func (c *Client) messageLimit() int {
+	if c.MessageLimit > 0 {
+		return c.MessageLimit
+	}
+	return DefaultMessageLimit
+}
+
+func (c *Client) modMessageLimit() int {
+	if c.ModMessageLimit > 0 {
+		return c.ModMessageLimit
+	}
+	return DefaultModMessageLimit
+}
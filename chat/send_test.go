@@ -0,0 +1,37 @@
+package chat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReserve(t *testing.T) {
+	c := &Client{MessageWindow: 30 * time.Second}
+	now := time.Unix(1000, 0)
+	c.sent = []time.Time{now.Add(-40 * time.Second), now.Add(-20 * time.Second), now.Add(-10 * time.Second)}
+	if wait := c.reserve(now, 3); wait != 0 {
+		t.Errorf("Expected no wait.  Got %v.", wait)
+	}
+	if len(c.sent) != 2 {
+		t.Errorf("Expected the messages outside the window to be dropped.  Got %v.", c.sent)
+	}
+	if wait := c.reserve(now, 2); wait != 10*time.Second {
+		t.Errorf("Expected %v.  Got %v.", 10*time.Second, wait)
+	}
+	if wait := c.reserve(now, 1); wait != 20*time.Second {
+		t.Errorf("Expected %v.  Got %v.", 20*time.Second, wait)
+	}
+}
+
+func TestMessageText(t *testing.T) {
+	text, err := messageText(" hello\r\nworld\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "hello world" {
+		t.Errorf("Expected %q.  Got %q.", "hello world", text)
+	}
+	if _, err := messageText("\n"); err == nil {
+		t.Error("Expected an error for an empty message.")
+	}
+}
@@ -1,10 +1,18 @@
 package twitch2go
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 // GetChatters returns the chatters for the given channel
 func (c *Client) GetChatters(channel string) (*ChatterResponse, error) {
-	resp, err := c.doChatters("GET", channel)
+	return c.GetChattersContext(context.Background(), channel)
+}
+
+// GetChattersContext is like GetChatters but uses the given context for the request.
+func (c *Client) GetChattersContext(ctx context.Context, channel string) (*ChatterResponse, error) {
+	resp, err := c.doChatters(ctx, "GET", channel)
 	if err != nil {
 		return nil, err
 	}
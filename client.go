@@ -1,13 +1,16 @@
 package twitch2go
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"path"
+	"time"
 
 	"golang.org/x/net/context/ctxhttp"
 
@@ -18,15 +21,30 @@ import (
 type Client struct {
 	ClientID   string
 	HTTPClient *http.Client
-	apiURL     *url.URL
+	// RetryPolicy controls retries of failed requests.  A nil policy disables retries.
+	RetryPolicy *RetryPolicy
+	// RateLimiter throttles requests to the API.  A nil limiter disables client side rate limiting.
+	RateLimiter *RateLimiter
+	// TokenSource supplies the oauth token of requests made without one, so methods like GetChannelSubscribers can be
+	// called with an empty oauth argument.  A nil TokenSource sends such requests unauthenticated.
+	TokenSource TokenSource
+	apiURL      *url.URL
+	chattersURL string
+	userAgent   string
+	headers     http.Header
+	logger      Logger
+	timeout     time.Duration
 }
 
 type doOptions struct {
-	params    map[string]string
-	forceJSON bool
-	headers   map[string]string
-	oauth     string
-	context   context.Context
+	params map[string]string
+	// data, if not nil, is encoded as the JSON body of the request.
+	data    interface{}
+	headers map[string]string
+	oauth   string
+	// scope is the oauth scope the endpoint requires.  Requests rejected for a missing scope return a ScopeError.
+	scope   string
+	context context.Context
 }
 
 const (
@@ -36,34 +54,47 @@ const (
 	ChatterEndpoint = "https://tmi.twitch.tv/group/user/%s/chatters"
 )
 
-// Error represents a failure from the API.
-type Error struct {
-	Status  int
-	Message string
-}
-
-func newError(resp *http.Response) *Error {
-	defer resp.Body.Close()
-	data, err := ioutil.ReadAll(resp.Body)
+// NewClient returns a Client for the given client ID, configured by the given options.  It returns an error if an
+// option is invalid.
+//
+//	client, err := twitch2go.NewClient(clientID,
+//		twitch2go.WithBaseURL("https://twitch-proxy.internal"),
+//		twitch2go.WithTimeout(10*time.Second),
+//	)
+func NewClient(ClientID string, opts ...Option) (*Client, error) {
+	url, err := parseBaseURL(apiURL)
 	if err != nil {
-		return &Error{Status: resp.StatusCode, Message: fmt.Sprintf("cannot read body, err: %v", err)}
+		return nil, errors.Trace(err)
 	}
-	return &Error{Status: resp.StatusCode, Message: string(data)}
-}
-
-func (e *Error) Error() string {
-	return fmt.Sprintf("API error (%d): %s", e.Status, e.Message)
+	retryPolicy := DefaultRetryPolicy
+	c := &Client{
+		ClientID:    ClientID,
+		apiURL:      url,
+		chattersURL: ChatterEndpoint,
+		HTTPClient:  cleanhttp.DefaultClient(),
+		RetryPolicy: &retryPolicy,
+		RateLimiter: NewRateLimiter(DefaultRateLimit, time.Minute),
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if c.timeout > 0 {
+		httpClient := *c.HTTPClient
+		httpClient.Timeout = c.timeout
+		c.HTTPClient = &httpClient
+	}
+	return c, nil
 }
 
-func NewClient(ClientID string) *Client {
-	url, err := url.Parse(apiURL)
-	if err != nil {
-		log.Fatal(err)
+// setHeaders sets the default headers and user agent configured by the options on req.
+func (c *Client) setHeaders(req *http.Request) {
+	for k, v := range c.headers {
+		req.Header[k] = append([]string(nil), v...)
 	}
-	return &Client{
-		ClientID:   ClientID,
-		apiURL:     url,
-		HTTPClient: cleanhttp.DefaultClient(),
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
 	}
 }
 
@@ -78,7 +109,6 @@ func chooseError(ctx context.Context, err error) error {
 }
 
 func (c *Client) do(method, urlPath string, doOptions *doOptions) (*http.Response, error) {
-	httpClient := c.HTTPClient
 	var u string
 	p := path.Join(apiPath, urlPath)
 	url, err := c.apiURL.Parse(p)
@@ -91,45 +121,113 @@ func (c *Client) do(method, urlPath string, doOptions *doOptions) (*http.Respons
 	}
 	url.RawQuery = params.Encode()
 	u = url.String()
-	req, err := http.NewRequest(method, u, nil)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	req.Header.Set("accept", "application/vnd.twitchtv.v5+json")
-	req.Header.Set("client-id", c.ClientID)
-	if doOptions.oauth != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("OAuth %s", doOptions.oauth))
+	var body []byte
+	if doOptions.data != nil {
+		body, err = json.Marshal(doOptions.data)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
-	for k, v := range doOptions.headers {
-		req.Header.Set(k, v)
+	request := func(oauth string) (*http.Response, error) {
+		newRequest := func() (*http.Request, error) {
+			var r io.Reader
+			if body != nil {
+				// Every attempt needs its own reader over the body.
+				r = bytes.NewReader(body)
+			}
+			req, err := http.NewRequest(method, u, r)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			c.setHeaders(req)
+			req.Header.Set("accept", "application/vnd.twitchtv.v5+json")
+			req.Header.Set("client-id", c.ClientID)
+			if body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if oauth != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("OAuth %s", oauth))
+			}
+			for k, v := range doOptions.headers {
+				req.Header.Set(k, v)
+			}
+			return req, nil
+		}
+		return send(doOptions.context, c.HTTPClient, c.RetryPolicy, c.RateLimiter, c.logger, method, newRequest)
 	}
-	ctx := doOptions.context
-	if ctx == nil {
-		ctx = context.Background()
+	var resp *http.Response
+	if doOptions.oauth == "" && c.TokenSource != nil {
+		resp, err = withToken(c.TokenSource, request)
+	} else {
+		resp, err = request(doOptions.oauth)
 	}
-	resp, err := ctxhttp.Do(ctx, httpClient, req)
 	if err != nil {
-		return nil, errors.Trace(chooseError(ctx, err))
-	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return nil, errors.Trace(newError(resp))
+		return nil, scopeError(err, doOptions.scope)
 	}
 	return resp, nil
 }
 
-func (c *Client) doChatters(method, channel string) (*http.Response, error) {
-	httpClient := c.HTTPClient
-	u := fmt.Sprintf(ChatterEndpoint, channel)
-	req, err := http.NewRequest(method, u, nil)
-	if err != nil {
-		return nil, errors.Trace(err)
+func (c *Client) doChatters(ctx context.Context, method, channel string) (*http.Response, error) {
+	u := fmt.Sprintf(c.chattersURL, url.PathEscape(channel))
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequest(method, u, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.setHeaders(req)
+		return req, nil
 	}
-	resp, err := ctxhttp.Do(context.Background(), httpClient, req)
-	if err != nil {
-		return nil, errors.Trace(chooseError(context.Background(), err))
+	return send(ctx, c.HTTPClient, c.RetryPolicy, nil, c.logger, method, newRequest)
+}
+
+// send performs the request built by newRequest with httpClient, retrying it according to policy.  newRequest is called
+// once per attempt so every attempt gets a fresh request.  If limiter is not nil every attempt waits for it and feeds
+// the response headers back to it.  Retries are reported to logger when it is not nil.
+func send(ctx context.Context, httpClient *http.Client, policy *RetryPolicy, limiter *RateLimiter, logger Logger, method string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return nil, errors.Trace(newError(resp))
+	if policy == nil {
+		policy = &NoRetry
+	}
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		resp, err := ctxhttp.Do(ctx, httpClient, req)
+		if err == nil && limiter != nil {
+			limiter.Update(resp.Header)
+		}
+		if err != nil {
+			err = chooseError(ctx, err)
+			if !policy.retryError(method, attempt, err) {
+				return nil, errors.Trace(err)
+			}
+		} else if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			if !policy.retryStatus(method, attempt, resp.StatusCode) {
+				return nil, errors.Trace(newError(resp))
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			return resp, nil
+		}
+		delay := policy.delay(attempt, resp)
+		if logger != nil {
+			reason := fmt.Sprint(err)
+			if err == nil {
+				reason = fmt.Sprintf("status %d", resp.StatusCode)
+			}
+			logger.Printf("twitch2go: retrying %s %s in %s after attempt %d: %s", method, req.URL.Redacted(), delay, attempt, reason)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
-	return resp, nil
 }
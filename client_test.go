@@ -0,0 +1,71 @@
+package twitch2go
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// FakeRoundTripper answers every request with the same canned body and status.
+type FakeRoundTripper struct {
+	message  string
+	status   int
+	header   map[string]string
+	requests []*http.Request
+}
+
+func (rt *FakeRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, r)
+	resp := &http.Response{
+		StatusCode: rt.status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(rt.message)),
+		Request:    r,
+	}
+	for k, v := range rt.header {
+		resp.Header.Set(k, v)
+	}
+	return resp, nil
+}
+
+func newTestClient(rt http.RoundTripper, opts ...Option) *Client {
+	opts = append([]Option{WithHTTPClient(&http.Client{Transport: rt})}, opts...)
+	client, err := NewClient("fakeclientid", opts...)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// slowRoundTripper never answers, it blocks until the request's context is done.
+type slowRoundTripper struct {
+	calls int
+}
+
+func (rt *slowRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.calls++
+	<-r.Context().Done()
+	return nil, r.Context().Err()
+}
+
+func TestContextCancelsRequest(t *testing.T) {
+	rt := &slowRoundTripper{}
+	client := newTestClient(rt)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	_, err := client.GetChannelByIDContext(ctx, "6391593")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled.  Got %v.", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the request to stop when the context was cancelled.  Took %s.", elapsed)
+	}
+	if rt.calls != 1 {
+		t.Errorf("Expected 1 attempt.  Got %d.", rt.calls)
+	}
+}
@@ -0,0 +1,141 @@
+package twitch2go
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ClipPeriod is the window of time top clips are taken from.
+type ClipPeriod string
+
+const (
+	ClipsDay   ClipPeriod = "day"
+	ClipsWeek  ClipPeriod = "week"
+	ClipsMonth ClipPeriod = "month"
+	ClipsAll   ClipPeriod = "all"
+)
+
+// GetClip returns the clip with the given slug, the last part of its URL.
+func (c *Client) GetClip(slug string) (*Clip, error) {
+	return c.GetClipContext(context.Background(), slug)
+}
+
+// GetClipContext is like GetClip but uses the given context for the request.
+func (c *Client) GetClipContext(ctx context.Context, slug string) (*Clip, error) {
+	url := "/clips/" + slug
+	// Do the request
+	resp, err := c.do("GET", url, &doOptions{context: ctx})
+	if err != nil {
+		return nil, errors.Annotate(err, "GetClip")
+	}
+	defer resp.Body.Close()
+	clip := &Clip{}
+	err = json.NewDecoder(resp.Body).Decode(clip)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return clip, nil
+}
+
+// TopClipsOptions selects the clips returned by GetTopClips.  Empty fields are not sent.
+type TopClipsOptions struct {
+	// Channels are channel names to take clips from.  Up to 10.
+	Channels []string
+	// Game is the name of a game to take clips from.
+	Game string
+	// Languages are language codes like `en`.  Up to 28.
+	Languages []string
+	// Period is one of ClipsDay, ClipsWeek, ClipsMonth and ClipsAll.  Default is ClipsWeek.
+	Period ClipPeriod
+	// Trending orders clips by how fast they gain views instead of by views.
+	Trending bool
+	// Limit is the number of clips to return.  Max is 100, default is 10.
+	Limit int
+	// Cursor is the cursor of the page to return, from a previous response.
+	Cursor string
+}
+
+// GetTopClips returns the most viewed clips selected by opts.  Pass the returned Cursor as opts.Cursor to get the next
+// page.
+func (c *Client) GetTopClips(opts TopClipsOptions) (*Clips, error) {
+	return c.GetTopClipsContext(context.Background(), opts)
+}
+
+// GetTopClipsContext is like GetTopClips but uses the given context for the request.
+func (c *Client) GetTopClipsContext(ctx context.Context, opts TopClipsOptions) (*Clips, error) {
+	params := map[string]string{
+		"limit":    strconv.Itoa(pageLimit(opts.Limit, 10)),
+		"trending": strconv.FormatBool(opts.Trending),
+	}
+	for k, v := range map[string]string{
+		"channel":  strings.Join(opts.Channels, ","),
+		"game":     opts.Game,
+		"language": strings.Join(opts.Languages, ","),
+		"period":   string(opts.Period),
+		"cursor":   opts.Cursor,
+	} {
+		if v != "" {
+			params[k] = v
+		}
+	}
+	return c.getClips("GetTopClips", "/clips/top", &doOptions{params: params, context: ctx})
+}
+
+/*
+GetFollowedClips returns the top clips of the games the user follows.  Requires an oauth token from the user with the
+`user_read` scope.
+
+The function takes in four parameters:
+
+	oauth:
+		User oauth token
+
+	limit:
+		The number of clips to return.  Max is 100, default is 10.
+
+	cursor:
+		Cursor of the page to return, from a previous response.
+
+	trending:
+		Order clips by how fast they gain views instead of by views.
+*/
+func (c *Client) GetFollowedClips(oauth string, limit int, cursor string, trending bool) (*Clips, error) {
+	return c.GetFollowedClipsContext(context.Background(), oauth, limit, cursor, trending)
+}
+
+// GetFollowedClipsContext is like GetFollowedClips but uses the given context for the request.
+func (c *Client) GetFollowedClipsContext(ctx context.Context, oauth string, limit int, cursor string, trending bool) (*Clips, error) {
+	opts := &doOptions{
+		params: map[string]string{
+			"limit":    strconv.Itoa(pageLimit(limit, 10)),
+			"trending": strconv.FormatBool(trending),
+		},
+		oauth:   oauth,
+		scope:   "user_read",
+		context: ctx,
+	}
+	if cursor != "" {
+		opts.params["cursor"] = cursor
+	}
+	return c.getClips("GetFollowedClips", "/clips/followed", opts)
+}
+
+// getClips requests a page of clips, annotating errors with the name of the calling method.
+func (c *Client) getClips(method string, url string, opts *doOptions) (*Clips, error) {
+	// Do the request
+	resp, err := c.do("GET", url, opts)
+	if err != nil {
+		return nil, errors.Annotate(err, method)
+	}
+	defer resp.Body.Close()
+	clips := &Clips{}
+	err = json.NewDecoder(resp.Body).Decode(clips)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return clips, nil
+}
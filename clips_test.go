@@ -0,0 +1,168 @@
+package twitch2go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+const clipJSON = `{
+  "slug": "AmazonianEncouragingLyrebirdAllenHuhu",
+  "tracking_id": "2203857",
+  "url": "https://clips.twitch.tv/AmazonianEncouragingLyrebirdAllenHuhu?tt_medium=clips_api&tt_content=url",
+  "embed_url": "https://clips.twitch.tv/embed?clip=AmazonianEncouragingLyrebirdAllenHuhu&tt_medium=clips_api&tt_content=embed",
+  "embed_html": "<iframe src='https://clips.twitch.tv/embed?clip=AmazonianEncouragingLyrebirdAllenHuhu&tt_medium=clips_api&tt_content=embed' width='640' height='360' frameborder='0' scrolling='no' allowfullscreen='true'></iframe>",
+  "broadcaster": {
+    "id": "129454141",
+    "name": "twitch",
+    "display_name": "Twitch",
+    "channel_url": "https://www.twitch.tv/twitch",
+    "logo": "https://static-cdn.jtvnw.net/jtv_user_pictures/twitch-profile_image-8a8c5be2e3b64a9a-150x150.png"
+  },
+  "curator": {
+    "id": "6391593",
+    "name": "chosenken",
+    "display_name": "Chosenken",
+    "channel_url": "https://www.twitch.tv/chosenken",
+    "logo": ""
+  },
+  "vod": {
+    "id": "118979643",
+    "url": "https://www.twitch.tv/videos/118979643?t=51m25s",
+    "offset": 3085,
+    "preview_image_url": "https://vod-secure.twitch.tv/_404/404_processing_320x240.png"
+  },
+  "broadcast_id": "25100336368",
+  "game": "Creative",
+  "language": "en",
+  "title": "Amazonian ball",
+  "views": 2055,
+  "duration": 30.0,
+  "created_at": "2017-02-17T23:21:26Z",
+  "thumbnails": {
+    "medium": "https://clips-media-assets.twitch.tv/157589949-preview-480x272.jpg",
+    "small": "https://clips-media-assets.twitch.tv/157589949-preview-260x147.jpg",
+    "tiny": "https://clips-media-assets.twitch.tv/157589949-preview-86x45.jpg"
+  }
+}`
+
+func TestGetClip(t *testing.T) {
+	var expected Clip
+	err := json.Unmarshal([]byte(clipJSON), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: clipJSON, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	clip, err := client.GetClip("AmazonianEncouragingLyrebirdAllenHuhu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*clip, expected) {
+		t.Errorf("GetClip: Expected %#v.  Got %#v.", expected, *clip)
+	}
+	if clip.VOD == nil || clip.VOD.Offset != 3085 || clip.Curator.Name != "chosenken" {
+		t.Errorf("Unexpected clip %#v", *clip)
+	}
+	if path := fakeRT.requests[0].URL.Path; path != "/kraken/clips/AmazonianEncouragingLyrebirdAllenHuhu" {
+		t.Errorf("Unexpected path %s", path)
+	}
+}
+
+func TestGetTopClips(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"clips": [` + clipJSON + `], "_cursor": "MTA="}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	clips, err := client.GetTopClips(TopClipsOptions{
+		Channels:  []string{"twitch", "chosenken"},
+		Languages: []string{"en", "de"},
+		Period:    ClipsMonth,
+		Trending:  true,
+		Limit:     1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clips.Clips) != 1 || clips.Cursor != "MTA=" {
+		t.Errorf("Unexpected clips %#v", *clips)
+	}
+	q := fakeRT.requests[0].URL.Query()
+	expected := map[string]string{"channel": "twitch,chosenken", "language": "en,de", "period": "month", "trending": "true", "limit": "1", "game": "", "cursor": ""}
+	for k, v := range expected {
+		if q.Get(k) != v {
+			t.Errorf("%s: Expected %#v.  Got %#v.", k, v, q.Get(k))
+		}
+	}
+}
+
+func TestGetFollowedClipsScope(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"error":"Unauthorized","status":401,"message":"Token invalid or missing required scope"}`, status: http.StatusUnauthorized}
+	client := newTestClient(fakeRT)
+	_, err := client.GetFollowedClips("fakeoauth", 10, "", false)
+	if !IsMissingScope(err) {
+		t.Errorf("Expected a missing scope error.  Got %v.", err)
+	}
+}
+
+// clipsRoundTripper serves total clips in cursor paged pages.  The cursor is the string form of the next offset.
+type clipsRoundTripper struct {
+	total   int
+	cursors []string
+}
+
+func (rt *clipsRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	q := r.URL.Query()
+	rt.cursors = append(rt.cursors, q.Get("cursor"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	offset, _ := strconv.Atoi(q.Get("cursor"))
+	clips := Clips{Clips: []Clip{}}
+	for i := offset; i < offset+limit && i < rt.total; i++ {
+		clips.Clips = append(clips.Clips, Clip{Slug: strconv.Itoa(i)})
+	}
+	if offset+limit < rt.total {
+		clips.Cursor = strconv.Itoa(offset + limit)
+	}
+	body, _ := json.Marshal(clips)
+	return (&FakeRoundTripper{message: string(body), status: http.StatusOK}).RoundTrip(r)
+}
+
+func TestIterateFollowedClips(t *testing.T) {
+	rt := &clipsRoundTripper{total: 25}
+	client := newTestClient(rt)
+	it := client.IterateFollowedClips("fakeoauth", 10, true)
+	n := 0
+	for it.Next(context.Background()) {
+		if it.Value().Slug != strconv.Itoa(n) {
+			t.Fatalf("Expected clip %d.  Got %s.", n, it.Value().Slug)
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 25 {
+		t.Errorf("Expected 25 clips.  Got %d.", n)
+	}
+	expected := []string{"", "10", "20"}
+	if !reflect.DeepEqual(rt.cursors, expected) {
+		t.Errorf("Expected %#v.  Got %#v.", expected, rt.cursors)
+	}
+}
+
+func TestIterateTopClipsStartsAtCursor(t *testing.T) {
+	rt := &clipsRoundTripper{total: 30}
+	client := newTestClient(rt)
+	it := client.IterateTopClips(TopClipsOptions{Limit: 10, Cursor: "20"})
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 10 || len(rt.cursors) != 1 {
+		t.Errorf("Expected 10 clips in 1 request.  Got %d in %d.", n, len(rt.cursors))
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+)
+
+// maxLimit is the largest page the API returns.
+const maxLimit = 100
+
+// listFlags registers the paging flags of list commands.
+func (c *cli) listFlags(fs *flag.FlagSet) {
+	c.paged = true
+	fs.IntVar(&c.limit, "limit", 25, "number of results per page, 1 to 100")
+	fs.BoolVar(&c.all, "all", false, "fetch every page")
+}
+
+// pageSize returns the page size to request, the largest one when fetching every page.
+func (c *cli) pageSize() int {
+	if c.all {
+		return maxLimit
+	}
+	return c.limit
+}
+
+func direction(s string) (twitch2go.Direction, error) {
+	switch d := twitch2go.Direction(strings.ToLower(s)); d {
+	case twitch2go.ASC, twitch2go.DESC:
+		return d, nil
+	}
+	return "", &usageError{fmt.Sprintf("unknown direction %q, use asc or desc", s)}
+}
+
+func channelGet(fs *flag.FlagSet, c *cli) runner {
+	return func(ctx context.Context, args []string) (*result, error) {
+		var channel *twitch2go.Channel
+		var err error
+		if len(args) == 0 {
+			if c.oauth == "" {
+				return nil, &usageError{"pass a channel ID, or --oauth for the channel of the token"}
+			}
+			channel, err = c.client.GetChannelByOAuthContext(ctx, c.oauth)
+		} else {
+			channel, err = c.client.GetChannelByIDContext(ctx, args[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+		return channelsResult(channel, []twitch2go.Channel{*channel}), nil
+	}
+}
+
+func followsList(fs *flag.FlagSet, c *cli) runner {
+	c.listFlags(fs)
+	user := fs.Bool("user", false, "list the channels the given user follows instead of the followers of a channel")
+	dir := fs.String("direction", "desc", "sort direction by follow date: asc or desc")
+	cursor := fs.String("cursor", "", "cursor of the page to fetch, for the followers of a channel")
+	offset := fs.Int("offset", 0, "offset of the page to fetch, for the channels a user follows")
+	return func(ctx context.Context, args []string) (*result, error) {
+		d, err := direction(*dir)
+		if err != nil {
+			return nil, err
+		}
+		var follows []twitch2go.Follow
+		next := ""
+		switch {
+		case c.all:
+			it := c.client.IterateChannelFollows(args[0], c.pageSize(), d)
+			if *user {
+				it = c.client.IterateUserFollows(args[0], c.pageSize(), d, twitch2go.CreatedAt)
+			}
+			for it.Next(ctx) {
+				follows = append(follows, it.Value())
+			}
+			if err := it.Err(); err != nil {
+				return nil, err
+			}
+		case *user:
+			page, err := c.client.GetUserFollowsContext(ctx, args[0], c.pageSize(), *offset, d, twitch2go.CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+			follows = page.Follows
+			if end := *offset + len(follows); len(follows) > 0 && uint(end) < page.Total {
+				next = "--offset " + strconv.Itoa(end)
+			}
+		default:
+			page, err := c.client.GetChannelFollowsContext(ctx, args[0], *cursor, c.pageSize(), d)
+			if err != nil {
+				return nil, err
+			}
+			follows = page.Follows
+			if page.Cursor != "" && len(follows) > 0 {
+				next = "--cursor " + page.Cursor
+			}
+		}
+		res := followsResult(follows, *user)
+		res.next = next
+		return res, nil
+	}
+}
+
+func subsList(fs *flag.FlagSet, c *cli) runner {
+	c.listFlags(fs)
+	dir := fs.String("direction", "asc", "sort direction by subscription date: asc or desc")
+	offset := fs.Int("offset", 0, "offset of the page to fetch")
+	return func(ctx context.Context, args []string) (*result, error) {
+		d, err := direction(*dir)
+		if err != nil {
+			return nil, err
+		}
+		if c.oauth == "" {
+			return nil, &usageError{"subs list requires --oauth, a token of the channel with the channel_subscriptions scope"}
+		}
+		var subs []twitch2go.Subscription
+		next := ""
+		if c.all {
+			it := c.client.IterateChannelSubscribers(args[0], c.oauth, c.pageSize(), d)
+			for it.Next(ctx) {
+				subs = append(subs, it.Value())
+			}
+			if err := it.Err(); err != nil {
+				return nil, err
+			}
+		} else {
+			page, err := c.client.GetChannelSubscribersContext(ctx, args[0], c.oauth, c.pageSize(), *offset, d)
+			if err != nil {
+				return nil, err
+			}
+			subs = page.Subscriptions
+			if end := *offset + len(subs); len(subs) > 0 && uint(end) < page.Total {
+				next = "--offset " + strconv.Itoa(end)
+			}
+		}
+		res := subsResult(subs)
+		res.next = next
+		return res, nil
+	}
+}
+
+func videosList(fs *flag.FlagSet, c *cli) runner {
+	c.listFlags(fs)
+	broadcastType := fs.String("type", "", "comma separated broadcast types: archive, highlight and upload")
+	language := fs.String("language", "", "comma separated languages, like en")
+	sort := fs.String("sort", "time", "sort order: time or views")
+	offset := fs.Int("offset", 0, "offset of the page to fetch")
+	return func(ctx context.Context, args []string) (*result, error) {
+		videoSort := twitch2go.VideoSort(*sort)
+		if videoSort != twitch2go.Time && videoSort != twitch2go.Views {
+			return nil, &usageError{fmt.Sprintf("unknown sort %q, use time or views", *sort)}
+		}
+		var videos []twitch2go.Video
+		next := ""
+		if c.all {
+			it := c.client.IterateChannelVideos(args[0], c.pageSize(), *broadcastType, *language, videoSort)
+			for it.Next(ctx) {
+				videos = append(videos, it.Value())
+			}
+			if err := it.Err(); err != nil {
+				return nil, err
+			}
+		} else {
+			page, err := c.client.GetChannelVideosContext(ctx, args[0], c.pageSize(), *offset, *broadcastType, *language, videoSort)
+			if err != nil {
+				return nil, err
+			}
+			videos = page.Videos
+			if end := *offset + len(videos); len(videos) > 0 && uint(end) < page.Total {
+				next = "--offset " + strconv.Itoa(end)
+			}
+		}
+		res := videosResult(videos)
+		res.next = next
+		return res, nil
+	}
+}
+
+func streamGet(fs *flag.FlagSet, c *cli) runner {
+	return func(ctx context.Context, args []string) (*result, error) {
+		stream, err := c.client.GetStreamByChannelContext(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return streamsResult(stream, []twitch2go.Stream{*stream}), nil
+	}
+}
+
+func chatters(fs *flag.FlagSet, c *cli) runner {
+	return func(ctx context.Context, args []string) (*result, error) {
+		chatters, err := c.client.GetChattersContext(ctx, strings.ToLower(strings.TrimPrefix(args[0], "#")))
+		if err != nil {
+			return nil, err
+		}
+		return chattersResult(chatters), nil
+	}
+}
+
+func searchChannels(fs *flag.FlagSet, c *cli) runner {
+	return func(ctx context.Context, args []string) (*result, error) {
+		channels, err := c.client.SearchChannelsContext(ctx, strings.Join(args, " "))
+		if err != nil {
+			return nil, err
+		}
+		return channelsResult(*channels, *channels), nil
+	}
+}
+
+func searchUsers(fs *flag.FlagSet, c *cli) runner {
+	return func(ctx context.Context, args []string) (*result, error) {
+		users, err := c.client.SearchUsersContext(ctx, strings.Join(args, " "))
+		if err != nil {
+			return nil, err
+		}
+		return usersResult(*users), nil
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Command twitch2go calls the Twitch API from the command line.
+
+Usage:
+
+	twitch2go <command> [flags] [arguments]
+
+The commands are:
+
+	channel get [<channel-id>]    show a channel, or the channel of the --oauth token
+	follows list <channel-id>     list the followers of a channel, or with --user the channels a user follows
+	subs list <channel-id>        list the subscribers of a channel, requires --oauth
+	videos list <channel-id>      list the videos of a channel
+	stream get <channel-id>       show the live stream of a channel
+	chatters <channel-name>       list the users in a channel's chat
+	search channels <query>       search channels
+	search users <query>          search users
+
+Every command takes the flags:
+
+	--client-id   the Twitch client ID, defaults to $TWITCH_CLIENT_ID
+	--oauth       a user oauth token, defaults to $TWITCH_OAUTH
+	--output, -o  the output format: json, table or csv.  Default is json.
+
+List commands also take --limit, the page size from 1 to 100, and --all to fetch every page.  Without --all, the flag to
+pass for the next page is printed on stderr.
+
+twitch2go exits with status 1 when a request fails and 2 when it is used incorrectly.
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+)
+
+// runner runs a command with its positional arguments.
+type runner func(ctx context.Context, args []string) (*result, error)
+
+// command is a subcommand.  setup registers the command's own flags and returns the function running it.
+type command struct {
+	path    string
+	args    string
+	minArgs int
+	maxArgs int
+	setup   func(fs *flag.FlagSet, c *cli) runner
+}
+
+var commands = []command{
+	{path: "channel get", args: "[<channel-id>]", minArgs: 0, maxArgs: 1, setup: channelGet},
+	{path: "follows list", args: "<channel-id>", minArgs: 1, maxArgs: 1, setup: followsList},
+	{path: "subs list", args: "<channel-id>", minArgs: 1, maxArgs: 1, setup: subsList},
+	{path: "videos list", args: "<channel-id>", minArgs: 1, maxArgs: 1, setup: videosList},
+	{path: "stream get", args: "<channel-id>", minArgs: 1, maxArgs: 1, setup: streamGet},
+	{path: "chatters", args: "<channel-name>", minArgs: 1, maxArgs: 1, setup: chatters},
+	{path: "search channels", args: "<query>", minArgs: 1, maxArgs: -1, setup: searchChannels},
+	{path: "search users", args: "<query>", minArgs: 1, maxArgs: -1, setup: searchUsers},
+}
+
+// cli holds the flags shared by every command and the client built from them.
+type cli struct {
+	stdout io.Writer
+	stderr io.Writer
+
+	clientID    string
+	oauth       string
+	output      string
+	apiURL      string
+	chattersURL string
+	paged       bool
+	limit       int
+	all         bool
+
+	client *twitch2go.Client
+}
+
+// usageError is an error in the command line.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	status := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(status)
+}
+
+// run runs the command line args and returns the exit status.
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer, getenv func(string) string) int {
+	cmd, rest := findCommand(args)
+	if cmd == nil {
+		if len(args) > 0 && args[0] != "help" && args[0] != "-h" && args[0] != "--help" {
+			fmt.Fprintf(stderr, "twitch2go: unknown command %q\n\n", strings.Join(args, " "))
+		}
+		usage(stderr)
+		return 2
+	}
+	c := &cli{stdout: stdout, stderr: stderr}
+	fs := flag.NewFlagSet("twitch2go "+cmd.path, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: twitch2go %s [flags] %s\n\nflags:\n", cmd.path, cmd.args)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&c.clientID, "client-id", getenv("TWITCH_CLIENT_ID"), "Twitch client ID, defaults to $TWITCH_CLIENT_ID")
+	fs.StringVar(&c.oauth, "oauth", getenv("TWITCH_OAUTH"), "user oauth token, defaults to $TWITCH_OAUTH")
+	fs.StringVar(&c.output, "output", "json", "output format: json, table or csv")
+	fs.StringVar(&c.output, "o", "json", "shorthand for --output")
+	fs.StringVar(&c.apiURL, "api-url", "", "base URL of the API, for proxies and testing")
+	fs.StringVar(&c.chattersURL, "chatters-url", "", "URL template of the chatters endpoint, for proxies and testing")
+	runCmd := cmd.setup(fs, c)
+	positional, err := parseInterspersed(fs, rest)
+	if err == flag.ErrHelp {
+		return 2
+	}
+	if err == nil {
+		err = c.check(cmd, positional)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "twitch2go: %v\n", err)
+		fs.Usage()
+		return 2
+	}
+
+	var opts []twitch2go.Option
+	if c.apiURL != "" {
+		opts = append(opts, twitch2go.WithBaseURL(c.apiURL))
+	}
+	if c.chattersURL != "" {
+		opts = append(opts, twitch2go.WithChattersURL(c.chattersURL))
+	}
+	c.client, err = twitch2go.NewClient(c.clientID, opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "twitch2go: %v\n", err)
+		return 2
+	}
+	res, err := runCmd(ctx, positional)
+	if err != nil {
+		fmt.Fprintf(stderr, "twitch2go: %v\n", err)
+		if _, ok := err.(*usageError); ok {
+			fs.Usage()
+			return 2
+		}
+		return 1
+	}
+	if err := res.write(stdout, c.output); err != nil {
+		fmt.Fprintf(stderr, "twitch2go: %v\n", err)
+		return 1
+	}
+	if res.next != "" && !c.all {
+		fmt.Fprintf(stderr, "twitch2go: more results, pass --all or %s\n", res.next)
+	}
+	return 0
+}
+
+// check validates the shared flags and the number of positional arguments.
+func (c *cli) check(cmd *command, args []string) error {
+	if len(args) < cmd.minArgs || (cmd.maxArgs >= 0 && len(args) > cmd.maxArgs) {
+		return &usageError{fmt.Sprintf("%s takes %s", cmd.path, cmd.args)}
+	}
+	if c.clientID == "" {
+		return &usageError{"a client ID is required, pass --client-id or set TWITCH_CLIENT_ID"}
+	}
+	switch c.output {
+	case "json", "table", "csv":
+	default:
+		return &usageError{fmt.Sprintf("unknown output format %q", c.output)}
+	}
+	if c.paged && (c.limit < 1 || c.limit > maxLimit) {
+		return &usageError{fmt.Sprintf("--limit must be between 1 and %d, got %d", maxLimit, c.limit)}
+	}
+	return nil
+}
+
+// findCommand returns the command named by the first words of args and the remaining arguments.
+func findCommand(args []string) (*command, []string) {
+	for i := range commands {
+		words := strings.Fields(commands[i].path)
+		if len(args) < len(words) {
+			continue
+		}
+		match := true
+		for j, word := range words {
+			if args[j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return &commands[i], args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+// parseInterspersed parses flags placed before, between or after the positional arguments, which it returns.  A `--`
+// ends the flags.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: twitch2go <command> [flags] [arguments]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "\t%s %s\n", cmd.path, cmd.args)
+	}
+	fmt.Fprintf(w, "\nRun twitch2go <command> -h for the flags of a command.\n")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+	"github.com/kenXengineering/twitch2go/twitchtest"
+)
+
+// runTest runs the command line against srv and returns the exit status, stdout and stderr.
+func runTest(srv *twitchtest.Server, env map[string]string, args ...string) (int, string, string) {
+	if env == nil {
+		env = map[string]string{"TWITCH_CLIENT_ID": twitchtest.ClientID}
+	}
+	args = append(args, "--api-url", srv.URL, "--chatters-url", srv.URL+"/group/user/%s/chatters")
+	var stdout, stderr bytes.Buffer
+	status := run(context.Background(), args, &stdout, &stderr, func(key string) string { return env[key] })
+	return status, stdout.String(), stderr.String()
+}
+
+func TestChannelGet(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	status, stdout, stderr := runTest(srv, nil, "channel", "get", twitchtest.SeedChannelID)
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	channel := twitch2go.Channel{}
+	if err := json.Unmarshal([]byte(stdout), &channel); err != nil {
+		t.Fatal(err)
+	}
+	if channel.Name != twitchtest.SeedChannelName {
+		t.Errorf("Expected channel %q.  Got %q.", twitchtest.SeedChannelName, channel.Name)
+	}
+
+	// The channel of the oauth token, from the environment.
+	env := map[string]string{"TWITCH_CLIENT_ID": twitchtest.ClientID, "TWITCH_OAUTH": twitchtest.SeedOAuth}
+	status, stdout, stderr = runTest(srv, env, "channel", "get", "-o", "table")
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.HasPrefix(lines[1], twitchtest.SeedChannelID) {
+		t.Errorf("Unexpected table %q.", stdout)
+	}
+}
+
+func TestFollowsListPagination(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	status, stdout, stderr := runTest(srv, nil, "follows", "list", twitchtest.SeedChannelID, "--limit", "10", "--output", "csv")
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 11 || strings.Join(records[0], ",") != "user_id,user_name,display_name,followed_at,notifications" {
+		t.Errorf("Expected a header and 10 rows.  Got %v.", records)
+	}
+	if !strings.Contains(stderr, "--cursor ") {
+		t.Errorf("Expected a hint for the next page.  Got %q.", stderr)
+	}
+
+	status, stdout, stderr = runTest(srv, nil, "follows", "list", "--all", twitchtest.SeedChannelID)
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	follows := []twitch2go.Follow{}
+	if err := json.Unmarshal([]byte(stdout), &follows); err != nil {
+		t.Fatal(err)
+	}
+	if len(follows) != twitchtest.SeedFollowerCount {
+		t.Errorf("Expected %d follows.  Got %d.", twitchtest.SeedFollowerCount, len(follows))
+	}
+	if stderr != "" {
+		t.Errorf("Expected no hint with --all.  Got %q.", stderr)
+	}
+}
+
+func TestSubsAndVideosList(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	if status, _, stderr := runTest(srv, nil, "subs", "list", twitchtest.SeedChannelID); status != 2 || !strings.Contains(stderr, "--oauth") {
+		t.Errorf("Expected a usage error without oauth.  Got %d: %s", status, stderr)
+	}
+	status, stdout, stderr := runTest(srv, nil, "subs", "list", twitchtest.SeedChannelID, "--oauth", twitchtest.SeedOAuth, "--all")
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	subs := []twitch2go.Subscription{}
+	if err := json.Unmarshal([]byte(stdout), &subs); err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != twitchtest.SeedSubscriberCount {
+		t.Errorf("Expected %d subscriptions.  Got %d.", twitchtest.SeedSubscriberCount, len(subs))
+	}
+
+	status, stdout, stderr = runTest(srv, nil, "videos", "list", twitchtest.SeedChannelID, "--limit", "5", "-o", "csv")
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	if rows := strings.Count(stdout, "\n"); rows != 6 {
+		t.Errorf("Expected a header and 5 rows.  Got %d lines.", rows)
+	}
+	if !strings.Contains(stderr, "--offset 5") {
+		t.Errorf("Expected a hint for the next page.  Got %q.", stderr)
+	}
+}
+
+func TestStreamGetAndChatters(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	status, stdout, stderr := runTest(srv, nil, "stream", "get", twitchtest.SeedLiveChannelID, "-o", "csv")
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	if !strings.Contains(stdout, twitchtest.SeedLiveChannelName+",For Honor,5350") {
+		t.Errorf("Unexpected stream %q.", stdout)
+	}
+	if status, _, stderr := runTest(srv, nil, "stream", "get", twitchtest.SeedChannelID); status != 1 || !strings.Contains(stderr, "offline") {
+		t.Errorf("Expected an offline error.  Got %d: %s", status, stderr)
+	}
+
+	status, stdout, stderr = runTest(srv, nil, "chatters", "#"+twitchtest.SeedChannelName, "-o", "csv")
+	if status != 0 {
+		t.Fatalf("Expected status 0.  Got %d: %s", status, stderr)
+	}
+	if !strings.HasPrefix(stdout, "role,login\n") || strings.Count(stdout, "\n") < 2 {
+		t.Errorf("Unexpected chatters %q.", stdout)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	tests := []struct {
+		env  map[string]string
+		args []string
+	}{
+		{nil, []string{"bogus"}},
+		{nil, []string{"follows", "list"}},
+		{nil, []string{"channel", "get", "1", "-o", "xml"}},
+		{nil, []string{"follows", "list", "1", "--direction", "sideways"}},
+		{nil, []string{"follows", "list", "1", "--limit", "0"}},
+		{nil, []string{"videos", "list", "1", "--limit", "-5"}},
+		{nil, []string{"follows", "list", "1", "--limit", "101"}},
+		{map[string]string{}, []string{"channel", "get", "1"}},
+	}
+	for _, test := range tests {
+		if status, _, _ := runTest(srv, test.env, test.args...); status != 2 {
+			t.Errorf("Expected status 2 for %v.  Got %d.", test.args, status)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+)
+
+// result is the output of a command.  value is written as JSON, columns and rows as a table or CSV.
+type result struct {
+	value   interface{}
+	columns []string
+	rows    [][]string
+	// next is the flag fetching the next page, if there is one.
+	next string
+}
+
+func (r *result) write(w io.Writer, format string) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(r.columns, "\t")))
+		for _, row := range r.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(r.columns)
+		cw.WriteAll(r.rows)
+		return cw.Error()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.value)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatUint(u uint) string {
+	return strconv.FormatUint(uint64(u), 10)
+}
+
+// channelsResult lists channels.  value is what is written as JSON, a single channel or the list.
+func channelsResult(value interface{}, channels []twitch2go.Channel) *result {
+	r := &result{
+		value:   value,
+		columns: []string{"id", "name", "display_name", "game", "status", "followers", "views", "partner", "created_at"},
+	}
+	for _, ch := range channels {
+		r.rows = append(r.rows, []string{
+			ch.ID.String(), ch.Name, ch.DisplayName, ch.Game, ch.Status, formatUint(ch.Followers), formatUint(ch.Views),
+			strconv.FormatBool(ch.Partner), formatTime(ch.CreatedAt),
+		})
+	}
+	return r
+}
+
+func usersResult(users []twitch2go.User) *result {
+	r := &result{
+		value:   users,
+		columns: []string{"id", "name", "display_name", "type", "created_at"},
+	}
+	for _, u := range users {
+		r.rows = append(r.rows, []string{u.ID.String(), u.Name, u.DisplayName, u.Type, formatTime(u.CreatedAt)})
+	}
+	return r
+}
+
+// followsResult lists follows by follower, or by followed channel for the follows of a user.
+func followsResult(follows []twitch2go.Follow, byChannel bool) *result {
+	if follows == nil {
+		follows = []twitch2go.Follow{}
+	}
+	r := &result{
+		value:   follows,
+		columns: []string{"user_id", "user_name", "display_name", "followed_at", "notifications"},
+	}
+	if byChannel {
+		r.columns = []string{"channel_id", "channel_name", "display_name", "followed_at", "notifications"}
+	}
+	for _, f := range follows {
+		id, name, displayName := f.User.ID.String(), f.User.Name, f.User.DisplayName
+		if byChannel {
+			id, name, displayName = f.Channel.ID.String(), f.Channel.Name, f.Channel.DisplayName
+		}
+		r.rows = append(r.rows, []string{id, name, displayName, formatTime(f.CreatedAt), strconv.FormatBool(f.Notifications)})
+	}
+	return r
+}
+
+func subsResult(subs []twitch2go.Subscription) *result {
+	if subs == nil {
+		subs = []twitch2go.Subscription{}
+	}
+	r := &result{
+		value:   subs,
+		columns: []string{"id", "user_id", "user_name", "sub_plan", "is_gift", "created_at"},
+	}
+	for _, s := range subs {
+		r.rows = append(r.rows, []string{
+			s.ID, s.User.ID.String(), s.User.Name, s.SubPlan, strconv.FormatBool(s.IsGift), formatTime(s.CreatedAt),
+		})
+	}
+	return r
+}
+
+func videosResult(videos []twitch2go.Video) *result {
+	if videos == nil {
+		videos = []twitch2go.Video{}
+	}
+	r := &result{
+		value:   videos,
+		columns: []string{"id", "title", "game", "broadcast_type", "length", "views", "created_at"},
+	}
+	for _, v := range videos {
+		r.rows = append(r.rows, []string{
+			v.ID, v.Title, v.Game, v.BroadcastType, formatUint(v.Length), formatUint(v.Views), formatTime(v.CreatedAt),
+		})
+	}
+	return r
+}
+
+// streamsResult lists streams.  value is what is written as JSON, a single stream or the list.
+func streamsResult(value interface{}, streams []twitch2go.Stream) *result {
+	r := &result{
+		value:   value,
+		columns: []string{"id", "channel", "game", "viewers", "video_height", "average_fps", "created_at"},
+	}
+	for _, s := range streams {
+		r.rows = append(r.rows, []string{
+			s.ID.String(), s.Channel.Name, s.Game, formatUint(s.Viewers), formatUint(s.VideoHeight),
+			strconv.FormatFloat(s.AverageFps, 'f', -1, 64), formatTime(s.CreatedAt),
+		})
+	}
+	return r
+}
+
+// chattersResult lists chatters one per row, with their role.
+func chattersResult(chatters *twitch2go.ChatterResponse) *result {
+	r := &result{
+		value:   chatters,
+		columns: []string{"role", "login"},
+	}
+	roles := []struct {
+		name   string
+		logins []string
+	}{
+		{"moderator", chatters.Chatters.Moderators},
+		{"staff", chatters.Chatters.Staff},
+		{"admin", chatters.Chatters.Admins},
+		{"global_mod", chatters.Chatters.GlobalMods},
+		{"viewer", chatters.Chatters.Viewers},
+	}
+	for _, role := range roles {
+		for _, login := range role.logins {
+			r.rows = append(r.rows, []string{role.name, login})
+		}
+	}
+	return r
+}
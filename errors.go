@@ -0,0 +1,125 @@
+package twitch2go
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ErrStreamOffline is returned by GetStreamByChannel when the channel is not live.
+var ErrStreamOffline = errors.New("stream is offline")
+
+// Error represents a failure from the API.
+type Error struct {
+	// Status is the HTTP status code of the response.
+	Status int
+	// ErrorText is the short error description sent by Twitch, for example "Not Found".
+	ErrorText string
+	// Message is the error message sent by Twitch.  If the body of the response was not a Twitch JSON error, Message
+	// holds the raw body instead.
+	Message string
+}
+
+func newError(resp *http.Response) *Error {
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &Error{Status: resp.StatusCode, Message: fmt.Sprintf("cannot read body, err: %v", err)}
+	}
+	re := &ResponseError{}
+	if err := json.Unmarshal(data, re); err != nil || (re.Error == "" && re.Message == "") {
+		return &Error{Status: resp.StatusCode, ErrorText: http.StatusText(resp.StatusCode), Message: string(data)}
+	}
+	return &Error{Status: resp.StatusCode, ErrorText: re.Error, Message: re.Message}
+}
+
+func (e *Error) Error() string {
+	if e.ErrorText != "" && e.Message != "" && e.ErrorText != e.Message {
+		return fmt.Sprintf("API error (%d): %s: %s", e.Status, e.ErrorText, e.Message)
+	}
+	if e.Message == "" {
+		return fmt.Sprintf("API error (%d): %s", e.Status, e.ErrorText)
+	}
+	return fmt.Sprintf("API error (%d): %s", e.Status, e.Message)
+}
+
+// ScopeError is returned when a request is rejected because its oauth token lacks the scope the endpoint requires.
+type ScopeError struct {
+	// Scope is the scope the endpoint requires.
+	Scope string
+	// APIError is the error returned by the API.
+	APIError *Error
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("missing oauth scope %s: %s", e.Scope, e.APIError.Error())
+}
+
+// scopeError wraps err in a ScopeError if it is a 401 or 403 API error blaming a missing scope, keeping its trace and
+// annotations, and returns err otherwise.
+func scopeError(err error, scope string) error {
+	e, ok := errors.Cause(err).(*Error)
+	if !ok || scope == "" || (e.Status != http.StatusUnauthorized && e.Status != http.StatusForbidden) {
+		return err
+	}
+	if !strings.Contains(strings.ToLower(e.Message), "scope") {
+		return err
+	}
+	return errors.Wrap(err, &ScopeError{Scope: scope, APIError: e})
+}
+
+// AsError returns the API Error underlying err, looking through any annotations.
+func AsError(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+	switch e := errors.Cause(err).(type) {
+	case *Error:
+		return e, true
+	case *ScopeError:
+		return e.APIError, true
+	}
+	return nil, false
+}
+
+// IsMissingScope reports whether err was caused by an oauth token lacking the scope an endpoint requires.  Use
+// errors.Cause to get the *ScopeError naming the scope.
+func IsMissingScope(err error) bool {
+	_, ok := errors.Cause(err).(*ScopeError)
+	return ok
+}
+
+// IsStreamOffline reports whether err was caused by a channel not being live.
+func IsStreamOffline(err error) bool {
+	return errors.Cause(err) == ErrStreamOffline
+}
+
+func hasStatus(err error, status int) bool {
+	e, ok := AsError(err)
+	return ok && e.Status == status
+}
+
+// IsNotFound reports whether err was caused by a 404 Not Found response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err was caused by a 401 Unauthorized response, usually a missing or invalid oauth
+// token.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err was caused by a 403 Forbidden response.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err was caused by a 429 Too Many Requests response.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
@@ -0,0 +1,130 @@
+package twitch2go
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/juju/errors"
+)
+
+func TestErrorDecodesTwitchBody(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"error":"Not Found","status":404,"message":"Channel 'nobody' does not exist"}`, status: http.StatusNotFound}
+	client := newTestClient(fakeRT)
+	_, err := client.GetChannelByID("nobody")
+	if !IsNotFound(err) {
+		t.Fatalf("Expected a not found error.  Got %v.", err)
+	}
+	apiErr, ok := AsError(err)
+	if !ok {
+		t.Fatalf("Expected an *Error cause.  Got %T.", errors.Cause(err))
+	}
+	expected := Error{Status: 404, ErrorText: "Not Found", Message: "Channel 'nobody' does not exist"}
+	if *apiErr != expected {
+		t.Errorf("Expected %#v.  Got %#v.", expected, *apiErr)
+	}
+}
+
+func TestErrorKeepsRawBody(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: "<html>bad gateway</html>", status: http.StatusBadGateway}
+	client := newTestClient(fakeRT)
+	client.RetryPolicy = nil
+	_, err := client.GetUserByID("6391593")
+	apiErr, ok := AsError(err)
+	if !ok {
+		t.Fatalf("Expected an *Error cause.  Got %v.", err)
+	}
+	if apiErr.Message != "<html>bad gateway</html>" || apiErr.ErrorText != "Bad Gateway" {
+		t.Errorf("Expected the raw body and status text.  Got %#v.", *apiErr)
+	}
+}
+
+func TestErrorChecks(t *testing.T) {
+	tests := []struct {
+		status       int
+		notFound     bool
+		unauthorized bool
+		rateLimited  bool
+	}{
+		{http.StatusNotFound, true, false, false},
+		{http.StatusUnauthorized, false, true, false},
+		{http.StatusTooManyRequests, false, false, true},
+		{http.StatusInternalServerError, false, false, false},
+	}
+	for _, tt := range tests {
+		err := errors.Annotate(errors.Trace(&Error{Status: tt.status}), "annotated")
+		if IsNotFound(err) != tt.notFound || IsUnauthorized(err) != tt.unauthorized || IsRateLimited(err) != tt.rateLimited {
+			t.Errorf("status %d: got IsNotFound %v, IsUnauthorized %v, IsRateLimited %v", tt.status, IsNotFound(err), IsUnauthorized(err), IsRateLimited(err))
+		}
+	}
+	if IsNotFound(nil) || IsNotFound(errors.New("404")) {
+		t.Error("Expected non API errors not to be reported as not found.")
+	}
+}
+
+func TestCheckUserFollowsChannel(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"error":"Not Found","status":404,"message":"Follow not found"}`, status: http.StatusNotFound}
+	client := newTestClient(fakeRT)
+	following, err := client.CheckUserFollowsChannel("123", "456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if following {
+		t.Error("Expected user not to be following.")
+	}
+	fakeRT = &FakeRoundTripper{message: `{"created_at":"2016-12-15T14:55:49Z","notifications":false}`, status: http.StatusOK}
+	client = newTestClient(fakeRT)
+	following, err = client.CheckUserFollowsChannel("123", "456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !following {
+		t.Error("Expected user to be following.")
+	}
+}
+
+func TestCheckUserSubscriptionByChannel(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"error":"Not Found","status":404,"message":"123 has no subscriptions to 456"}`, status: http.StatusNotFound}
+	client := newTestClient(fakeRT)
+	subscribed, err := client.CheckUserSubscriptionByChannel("123", "456", "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscribed {
+		t.Error("Expected user not to be subscribed.")
+	}
+	fakeRT = &FakeRoundTripper{message: `{"error":"Unprocessable Entity","status":422,"message":"Channel does not have a subscription program"}`, status: http.StatusUnprocessableEntity}
+	client = newTestClient(fakeRT)
+	if _, err := client.CheckUserSubscriptionByChannel("123", "456", "fakeoauth"); err == nil {
+		t.Error("Expected an error for a channel without a subscription program.")
+	}
+}
+
+func TestScopeError(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"error":"Unauthorized","status":401,"message":"Token invalid or missing required scope"}`, status: http.StatusUnauthorized}
+	client := newTestClient(fakeRT)
+	_, err := client.GetChannelByOAuth("fakeoauth")
+	if !IsMissingScope(err) {
+		t.Fatalf("Expected a missing scope error.  Got %v.", err)
+	}
+	if !IsUnauthorized(err) {
+		t.Errorf("Expected the scope error to be unauthorized.  Got %v.", err)
+	}
+	scopeErr := errors.Cause(err).(*ScopeError)
+	if scopeErr.Scope != "channel_read" {
+		t.Errorf("Expected %#v.  Got %#v.", "channel_read", scopeErr.Scope)
+	}
+	if apiErr, ok := AsError(err); !ok || apiErr != scopeErr.APIError {
+		t.Errorf("Expected AsError to return the API error.  Got %#v.", apiErr)
+	}
+	// The trace of the failed request and the annotation of the method survive the wrapping.
+	if stack := errors.ErrorStack(err); !strings.Contains(stack, "twitch2go.send") || !strings.HasPrefix(err.Error(), "GetChannelByOAuth: ") {
+		t.Errorf("Expected the trace and annotations to be kept.  Got %s", stack)
+	}
+	fakeRT = &FakeRoundTripper{message: `{"error":"Unauthorized","status":401,"message":"invalid oauth token"}`, status: http.StatusUnauthorized}
+	client = newTestClient(fakeRT)
+	_, err = client.GetChannelByOAuth("fakeoauth")
+	if IsMissingScope(err) || !IsUnauthorized(err) {
+		t.Errorf("Expected a plain unauthorized error.  Got %v.", err)
+	}
+}
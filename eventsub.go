@@ -0,0 +1,324 @@
+package twitch2go
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// The EventSub subscription types with typed handlers in EventSubHandler.
+const (
+	EventSubStreamOnline     = "stream.online"
+	EventSubChannelFollow    = "channel.follow"
+	EventSubChannelSubscribe = "channel.subscribe"
+)
+
+// The EventSub message types, from the Twitch-Eventsub-Message-Type header.
+const (
+	eventSubVerification = "webhook_callback_verification"
+	eventSubNotification = "notification"
+	eventSubRevocation   = "revocation"
+)
+
+// DefaultEventSubMaxAge is the oldest message EventSubHandler accepts, if MaxAge is not set.  It is the limit Twitch
+// recommends.
+const DefaultEventSubMaxAge = 10 * time.Minute
+
+// maxEventSubBody bounds the size of the messages EventSubHandler reads.
+const maxEventSubBody = 1 << 20
+
+/*
+CreateEventSubSubscription subscribes to an EventSub event type and returns the new subscription, pending verification.
+Requires an app access token.
+
+The function takes in four parameters:
+
+	typ, version:
+		The subscription type, like EventSubStreamOnline, and its version, like `1`
+
+	condition:
+		The type specific condition, like `broadcaster_user_id`
+
+	transport:
+		The webhook callback URL and the secret Twitch signs the notifications with.  Method defaults to `webhook`.
+*/
+func (h *HelixClient) CreateEventSubSubscription(ctx context.Context, typ string, version string, condition map[string]string, transport EventSubTransport) (*EventSubSubscription, error) {
+	if transport.Method == "" {
+		transport.Method = "webhook"
+	}
+	body := struct {
+		Type      string            `json:"type"`
+		Version   string            `json:"version"`
+		Condition map[string]string `json:"condition"`
+		Transport EventSubTransport `json:"transport"`
+	}{typ, version, condition, transport}
+	data := []EventSubSubscription{}
+	_, err := h.doHelixMethod(ctx, "POST", "eventsub/subscriptions", url.Values{}, body, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "CreateEventSubSubscription")
+	}
+	if len(data) == 0 {
+		return nil, errors.Annotate(errors.New("no subscription returned"), "CreateEventSubSubscription")
+	}
+	return &data[0], nil
+}
+
+// EventSubSubscriptionsOptions filters the subscriptions returned by HelixClient.GetEventSubSubscriptions.  At most
+// one of Status, Type and UserID may be set.  Empty fields are not sent.
+type EventSubSubscriptionsOptions struct {
+	// Status is a subscription status, like `enabled` or `webhook_callback_verification_failed`.
+	Status string
+	Type   string
+	UserID string
+	After  string
+}
+
+// GetEventSubSubscriptions returns the EventSub subscriptions of the client ID.  Pass the returned Cursor as
+// opts.After to get the next page.  Requires an app access token.
+func (h *HelixClient) GetEventSubSubscriptions(ctx context.Context, opts EventSubSubscriptionsOptions) (*EventSubSubscriptions, error) {
+	params := url.Values{}
+	if opts.Status != "" {
+		params.Set("status", opts.Status)
+	}
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	if opts.UserID != "" {
+		params.Set("user_id", opts.UserID)
+	}
+	addPage(params, 0, opts.After)
+	data := []EventSubSubscription{}
+	envelope, err := h.doHelix(ctx, "eventsub/subscriptions", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetEventSubSubscriptions")
+	}
+	return &EventSubSubscriptions{
+		Total:         envelope.Total,
+		TotalCost:     envelope.TotalCost,
+		MaxTotalCost:  envelope.MaxTotalCost,
+		Cursor:        envelope.Pagination.Cursor,
+		Subscriptions: data,
+	}, nil
+}
+
+// DeleteEventSubSubscription deletes an EventSub subscription.  Requires an app access token.
+func (h *HelixClient) DeleteEventSubSubscription(ctx context.Context, id string) error {
+	params := url.Values{}
+	params.Set("id", id)
+	_, err := h.doHelixMethod(ctx, "DELETE", "eventsub/subscriptions", params, nil, nil)
+	if err != nil {
+		return errors.Annotate(err, "DeleteEventSubSubscription")
+	}
+	return nil
+}
+
+/*
+EventSubHandler is an http.Handler receiving EventSub webhook callbacks.  It verifies the signature of every message
+with Secret, rejects messages older than MaxAge, ignores messages it has already handled, answers the verification
+challenge of new subscriptions and passes notifications to the handler of their type.
+
+	handler := twitch2go.NewEventSubHandler(secret)
+	handler.OnStreamOnline = func(sub twitch2go.EventSubSubscription, e twitch2go.StreamOnlineEvent) {
+		log.Printf("%s went live", e.BroadcasterUserName)
+	}
+	http.Handle("/eventsub", handler)
+
+Handlers are called before the response is written, and Twitch gives up on responses slower than a few seconds, so
+long running work should be moved off the handler goroutine.  Set the handlers before serving requests.
+*/
+type EventSubHandler struct {
+	// Secret is the secret given when creating the subscriptions.
+	Secret string
+	// MaxAge is the age over which messages are rejected, as replays.  Default is DefaultEventSubMaxAge.
+	MaxAge time.Duration
+
+	OnStreamOnline     func(sub EventSubSubscription, e StreamOnlineEvent)
+	OnChannelFollow    func(sub EventSubSubscription, e ChannelFollowEvent)
+	OnChannelSubscribe func(sub EventSubSubscription, e ChannelSubscribeEvent)
+	// OnNotification is called with the raw event of every notification, before the typed handlers.
+	OnNotification func(sub EventSubSubscription, event json.RawMessage)
+	// OnRevocation is called when Twitch revokes a subscription.  sub.Status tells why, like `user_removed`.
+	OnRevocation func(sub EventSubSubscription)
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+	// order holds the IDs in seen oldest first, so expired IDs are dropped from the front.
+	order []seenMessage
+	now   func() time.Time
+}
+
+// seenMessage is a message ID and when it was handled.
+type seenMessage struct {
+	id string
+	at time.Time
+}
+
+// NewEventSubHandler returns an EventSubHandler verifying messages with the given secret.
+func NewEventSubHandler(secret string) *EventSubHandler {
+	return &EventSubHandler{
+		Secret: secret,
+		seen:   map[string]time.Time{},
+		now:    time.Now,
+	}
+}
+
+// eventSubMessage is the body of an EventSub message.
+type eventSubMessage struct {
+	Challenge    string               `json:"challenge"`
+	Subscription EventSubSubscription `json:"subscription"`
+	Event        json.RawMessage      `json:"event"`
+}
+
+// ServeHTTP handles an EventSub message.  Rejected messages get a 4xx status, which Twitch does not retry.
+func (h *EventSubHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventSubBody))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	id := r.Header.Get("Twitch-Eventsub-Message-Id")
+	timestamp := r.Header.Get("Twitch-Eventsub-Message-Timestamp")
+	if id == "" || !h.verify(id, timestamp, body, r.Header.Get("Twitch-Eventsub-Message-Signature")) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	sent, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		http.Error(w, "invalid timestamp", http.StatusBadRequest)
+		return
+	}
+	now := h.now()
+	if age := now.Sub(sent); age > h.maxAge() || age < -h.maxAge() {
+		http.Error(w, "stale message", http.StatusForbidden)
+		return
+	}
+	if !h.first(id, now) {
+		// Already handled, Twitch is retrying or the message is replayed.
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	m := eventSubMessage{}
+	if err := json.Unmarshal(body, &m); err != nil {
+		h.forget(id)
+		http.Error(w, "invalid message", http.StatusBadRequest)
+		return
+	}
+	switch r.Header.Get("Twitch-Eventsub-Message-Type") {
+	case eventSubVerification:
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, m.Challenge)
+		return
+	case eventSubNotification:
+		if err := h.dispatch(m.Subscription, m.Event); err != nil {
+			h.forget(id)
+			http.Error(w, "invalid event", http.StatusBadRequest)
+			return
+		}
+	case eventSubRevocation:
+		if h.OnRevocation != nil {
+			h.OnRevocation(m.Subscription)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify checks the HMAC-SHA256 signature of a message, `sha256=` followed by the hex encoded HMAC of the message ID,
+// timestamp and body.
+func (h *EventSubHandler) verify(id string, timestamp string, body []byte, signature string) bool {
+	const prefix = "sha256="
+	if len(signature) <= len(prefix) || signature[:len(prefix)] != prefix {
+		return false
+	}
+	expected, err := hex.DecodeString(signature[len(prefix):])
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	io.WriteString(mac, id)
+	io.WriteString(mac, timestamp)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// first records the message ID and reports whether it is seen for the first time.  IDs are kept for MaxAge, as older
+// messages are rejected anyway.  Only the expired IDs are visited, oldest first.
+func (h *EventSubHandler) first(id string, now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i := 0
+	for ; i < len(h.order) && now.Sub(h.order[i].at) > h.maxAge(); i++ {
+		old := h.order[i]
+		// The ID may have been forgotten, and seen again since.
+		if at, ok := h.seen[old.id]; ok && at.Equal(old.at) {
+			delete(h.seen, old.id)
+		}
+	}
+	h.order = h.order[i:]
+	if _, ok := h.seen[id]; ok {
+		return false
+	}
+	h.seen[id] = now
+	h.order = append(h.order, seenMessage{id, now})
+	return true
+}
+
+func (h *EventSubHandler) maxAge() time.Duration {
+	if h.MaxAge > 0 {
+		return h.MaxAge
+	}
+	return DefaultEventSubMaxAge
+}
+
+// forget removes a message ID that could not be handled, so that a retry by Twitch is handled.
+func (h *EventSubHandler) forget(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, id)
+}
+
+// dispatch decodes an event and calls the handler of its subscription type.
+func (h *EventSubHandler) dispatch(sub EventSubSubscription, event json.RawMessage) error {
+	if h.OnNotification != nil {
+		h.OnNotification(sub, event)
+	}
+	switch sub.Type {
+	case EventSubStreamOnline:
+		if h.OnStreamOnline != nil {
+			e := StreamOnlineEvent{}
+			if err := json.Unmarshal(event, &e); err != nil {
+				return errors.Trace(err)
+			}
+			h.OnStreamOnline(sub, e)
+		}
+	case EventSubChannelFollow:
+		if h.OnChannelFollow != nil {
+			e := ChannelFollowEvent{}
+			if err := json.Unmarshal(event, &e); err != nil {
+				return errors.Trace(err)
+			}
+			h.OnChannelFollow(sub, e)
+		}
+	case EventSubChannelSubscribe:
+		if h.OnChannelSubscribe != nil {
+			e := ChannelSubscribeEvent{}
+			if err := json.Unmarshal(event, &e); err != nil {
+				return errors.Trace(err)
+			}
+			h.OnChannelSubscribe(sub, e)
+		}
+	}
+	return nil
+}
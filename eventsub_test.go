@@ -0,0 +1,231 @@
+package twitch2go
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testEventSubSecret = "s3cr3t-s3cr3t"
+
+var testEventSubNow = time.Date(2019, 11, 16, 10, 11, 12, 0, time.UTC)
+
+// eventSubRequest builds a signed EventSub request.
+func eventSubRequest(id string, typ string, sent time.Time, body string) *http.Request {
+	timestamp := sent.Format(time.RFC3339Nano)
+	mac := hmac.New(sha256.New, []byte(testEventSubSecret))
+	mac.Write([]byte(id + timestamp + body))
+	req := httptest.NewRequest("POST", "/eventsub", strings.NewReader(body))
+	req.Header.Set("Twitch-Eventsub-Message-Id", id)
+	req.Header.Set("Twitch-Eventsub-Message-Timestamp", timestamp)
+	req.Header.Set("Twitch-Eventsub-Message-Type", typ)
+	req.Header.Set("Twitch-Eventsub-Message-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func newTestEventSubHandler() *EventSubHandler {
+	handler := NewEventSubHandler(testEventSubSecret)
+	handler.now = func() time.Time { return testEventSubNow }
+	return handler
+}
+
+func serveEventSub(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestEventSubChallenge(t *testing.T) {
+	body := `{"challenge":"pogchamp-kappa-360noscope-vohiyo","subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"webhook_callback_verification_pending","type":"channel.follow","version":"2","cost":1,"condition":{"broadcaster_user_id":"12826"},"transport":{"method":"webhook","callback":"https://example.com/webhooks/callback"},"created_at":"2019-11-16T10:11:12.634234626Z"}}`
+	w := serveEventSub(newTestEventSubHandler(), eventSubRequest("1", "webhook_callback_verification", testEventSubNow, body))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d.  Got %d.", http.StatusOK, w.Code)
+	}
+	if w.Body.String() != "pogchamp-kappa-360noscope-vohiyo" {
+		t.Errorf("Expected the challenge.  Got %q.", w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/plain" {
+		t.Errorf("Expected text/plain.  Got %q.", contentType)
+	}
+}
+
+func TestEventSubRejectsBadSignatureAndStaleMessages(t *testing.T) {
+	handler := newTestEventSubHandler()
+	body := `{"subscription":{"type":"stream.online"},"event":{}}`
+
+	req := eventSubRequest("1", "notification", testEventSubNow, body)
+	req.Header.Set("Twitch-Eventsub-Message-Signature", "sha256=00")
+	if w := serveEventSub(handler, req); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a bad signature.  Got %d.", http.StatusForbidden, w.Code)
+	}
+	req = eventSubRequest("2", "notification", testEventSubNow, body)
+	req.Body = ioutil.NopCloser(strings.NewReader(body + " "))
+	if w := serveEventSub(handler, req); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a tampered body.  Got %d.", http.StatusForbidden, w.Code)
+	}
+	req = eventSubRequest("3", "notification", testEventSubNow.Add(-11*time.Minute), body)
+	if w := serveEventSub(handler, req); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for a stale message.  Got %d.", http.StatusForbidden, w.Code)
+	}
+	req = eventSubRequest("4", "notification", testEventSubNow.Add(-9*time.Minute), body)
+	if w := serveEventSub(handler, req); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d for a recent message.  Got %d.", http.StatusNoContent, w.Code)
+	}
+}
+
+func TestEventSubDispatch(t *testing.T) {
+	handler := newTestEventSubHandler()
+	var online []StreamOnlineEvent
+	var follows []ChannelFollowEvent
+	var subs []ChannelSubscribeEvent
+	var raw []string
+	handler.OnStreamOnline = func(sub EventSubSubscription, e StreamOnlineEvent) { online = append(online, e) }
+	handler.OnChannelFollow = func(sub EventSubSubscription, e ChannelFollowEvent) { follows = append(follows, e) }
+	handler.OnChannelSubscribe = func(sub EventSubSubscription, e ChannelSubscribeEvent) { subs = append(subs, e) }
+	handler.OnNotification = func(sub EventSubSubscription, event json.RawMessage) { raw = append(raw, sub.Type) }
+
+	messages := []struct {
+		id   string
+		body string
+	}{
+		{"1", `{"subscription":{"type":"stream.online","version":"1"},"event":{"id":"9001","broadcaster_user_id":"1337","broadcaster_user_login":"cool_user","broadcaster_user_name":"Cool_User","type":"live","started_at":"2020-10-11T10:11:12.123Z"}}`},
+		{"2", `{"subscription":{"type":"channel.follow","version":"2"},"event":{"user_id":"1234","user_login":"cool_user","user_name":"Cool_User","broadcaster_user_id":"1337","broadcaster_user_login":"cooler_user","broadcaster_user_name":"Cooler_User","followed_at":"2020-07-15T18:16:11.17106713Z"}}`},
+		{"3", `{"subscription":{"type":"channel.subscribe","version":"1"},"event":{"user_id":"1234","user_login":"cool_user","user_name":"Cool_User","broadcaster_user_id":"1337","broadcaster_user_login":"cooler_user","broadcaster_user_name":"Cooler_User","tier":"1000","is_gift":false}}`},
+		// Retried by Twitch, it is not handled again.
+		{"3", `{"subscription":{"type":"channel.subscribe","version":"1"},"event":{"user_id":"1234","user_login":"cool_user","user_name":"Cool_User","broadcaster_user_id":"1337","broadcaster_user_login":"cooler_user","broadcaster_user_name":"Cooler_User","tier":"1000","is_gift":false}}`},
+		{"4", `{"subscription":{"type":"channel.update","version":"1"},"event":{}}`},
+	}
+	for _, m := range messages {
+		if w := serveEventSub(handler, eventSubRequest(m.id, "notification", testEventSubNow, m.body)); w.Code != http.StatusNoContent {
+			t.Errorf("Expected status %d.  Got %d.", http.StatusNoContent, w.Code)
+		}
+	}
+
+	expectedOnline := []StreamOnlineEvent{{
+		ID:                   "9001",
+		BroadcasterUserID:    "1337",
+		BroadcasterUserLogin: "cool_user",
+		BroadcasterUserName:  "Cool_User",
+		Type:                 "live",
+		StartedAt:            time.Date(2020, 10, 11, 10, 11, 12, 123000000, time.UTC),
+	}}
+	if !reflect.DeepEqual(online, expectedOnline) {
+		t.Errorf("Expected %#v.  Got %#v.", expectedOnline, online)
+	}
+	if len(follows) != 1 || follows[0].UserLogin != "cool_user" || follows[0].BroadcasterUserID != "1337" {
+		t.Errorf("Unexpected follows %#v.", follows)
+	}
+	expectedSubs := []ChannelSubscribeEvent{{
+		UserID:               "1234",
+		UserLogin:            "cool_user",
+		UserName:             "Cool_User",
+		BroadcasterUserID:    "1337",
+		BroadcasterUserLogin: "cooler_user",
+		BroadcasterUserName:  "Cooler_User",
+		Tier:                 "1000",
+	}}
+	if !reflect.DeepEqual(subs, expectedSubs) {
+		t.Errorf("Expected %#v.  Got %#v.", expectedSubs, subs)
+	}
+	expectedRaw := []string{"stream.online", "channel.follow", "channel.subscribe", "channel.update"}
+	if !reflect.DeepEqual(raw, expectedRaw) {
+		t.Errorf("Expected %v.  Got %v.", expectedRaw, raw)
+	}
+}
+
+func TestEventSubRevocation(t *testing.T) {
+	handler := newTestEventSubHandler()
+	var revoked EventSubSubscription
+	handler.OnRevocation = func(sub EventSubSubscription) { revoked = sub }
+	body := `{"subscription":{"id":"f1c2a387","status":"authorization_revoked","type":"channel.follow","version":"2"}}`
+	if w := serveEventSub(handler, eventSubRequest("1", "revocation", testEventSubNow, body)); w.Code != http.StatusNoContent {
+		t.Errorf("Expected status %d.  Got %d.", http.StatusNoContent, w.Code)
+	}
+	if revoked.ID != "f1c2a387" || revoked.Status != "authorization_revoked" {
+		t.Errorf("Unexpected revoked subscription %#v.", revoked)
+	}
+}
+
+func TestCreateEventSubSubscription(t *testing.T) {
+	jsonResponse := `{"data":[{"id":"26b1c993-bfcf-44d9-b876-379dacafe75a","status":"webhook_callback_verification_pending","type":"stream.online","version":"1","condition":{"broadcaster_user_id":"1234"},"created_at":"2020-11-10T14:32:18.730260295Z","transport":{"method":"webhook","callback":"https://example.com/eventsub"},"cost":1}],"total":1,"total_cost":1,"max_total_cost":10000}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusAccepted}
+	client := newTestHelixClient(fakeRT)
+	sub, err := client.CreateEventSubSubscription(context.Background(), EventSubStreamOnline, "1", map[string]string{"broadcaster_user_id": "1234"}, EventSubTransport{Callback: "https://example.com/eventsub", Secret: testEventSubSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.ID != "26b1c993-bfcf-44d9-b876-379dacafe75a" || sub.Status != "webhook_callback_verification_pending" || sub.Cost != 1 {
+		t.Errorf("Unexpected subscription %#v.", sub)
+	}
+	req := fakeRT.requests[0]
+	if req.Method != "POST" || req.URL.Path != "/helix/eventsub/subscriptions" {
+		t.Errorf("Expected POST /helix/eventsub/subscriptions.  Got %s %s.", req.Method, req.URL.Path)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"type":"stream.online","version":"1","condition":{"broadcaster_user_id":"1234"},"transport":{"method":"webhook","callback":"https://example.com/eventsub","secret":"s3cr3t-s3cr3t"}}`
+	if string(body) != expected {
+		t.Errorf("Expected %s.  Got %s.", expected, body)
+	}
+}
+
+func TestGetEventSubSubscriptions(t *testing.T) {
+	jsonResponse := `{"total":2,"data":[{"id":"1","status":"enabled","type":"stream.online","version":"1","condition":{"broadcaster_user_id":"1234"},"transport":{"method":"webhook","callback":"https://example.com/eventsub"},"cost":1}],"total_cost":1,"max_total_cost":10000,"pagination":{"cursor":"abc"}}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT)
+	subs, err := client.GetEventSubSubscriptions(context.Background(), EventSubSubscriptionsOptions{Status: "enabled"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs.Total != 2 || subs.TotalCost != 1 || subs.MaxTotalCost != 10000 || subs.Cursor != "abc" || len(subs.Subscriptions) != 1 {
+		t.Errorf("Unexpected subscriptions %#v.", subs)
+	}
+	if query := fakeRT.requests[0].URL.RawQuery; query != "status=enabled" {
+		t.Errorf("Expected %q.  Got %q.", "status=enabled", query)
+	}
+}
+
+func TestDeleteEventSubSubscription(t *testing.T) {
+	fakeRT := &FakeRoundTripper{status: http.StatusNoContent}
+	client := newTestHelixClient(fakeRT)
+	if err := client.DeleteEventSubSubscription(context.Background(), "26b1c993"); err != nil {
+		t.Fatal(err)
+	}
+	req := fakeRT.requests[0]
+	if req.Method != "DELETE" || req.URL.RawQuery != "id=26b1c993" {
+		t.Errorf("Expected DELETE with id=26b1c993.  Got %s %q.", req.Method, req.URL.RawQuery)
+	}
+}
+
+func TestEventSubFirstExpires(t *testing.T) {
+	handler := newTestEventSubHandler()
+	start := testEventSubNow
+	if !handler.first("a", start) || handler.first("a", start.Add(time.Minute)) {
+		t.Fatal("Expected a to be seen once.")
+	}
+	handler.first("b", start)
+	handler.forget("b")
+	handler.first("b", start.Add(5*time.Minute))
+	// a expired, b was seen again after it was forgotten and has not.
+	handler.first("c", start.Add(DefaultEventSubMaxAge+time.Second))
+	if len(handler.seen) != 2 || len(handler.order) != 2 {
+		t.Errorf("Expected b and c to be kept.  Got %v and %v.", handler.seen, handler.order)
+	}
+	if handler.first("b", start.Add(DefaultEventSubMaxAge+time.Second)) {
+		t.Error("Expected b to still be seen.")
+	}
+	if !handler.first("a", start.Add(DefaultEventSubMaxAge+time.Second)) {
+		t.Error("Expected a to be forgotten once expired.")
+	}
+}
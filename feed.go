@@ -0,0 +1,314 @@
+package twitch2go
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+// EndorseReaction is the emote ID of the default feed reaction.
+const EndorseReaction = "endorse"
+
+// feedContent is the body of requests creating posts and comments.
+type feedContent struct {
+	Content string `json:"content"`
+}
+
+// doFeed performs a channel feed request and decodes the response into v, annotating errors with the name of the
+// calling method.
+func (c *Client) doFeed(method string, name string, url string, opts *doOptions, v interface{}) error {
+	// Do the request
+	resp, err := c.do(method, url, opts)
+	if err != nil {
+		return errors.Annotate(err, name)
+	}
+	defer resp.Body.Close()
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return errors.Annotate(err, "Error decoding JSON")
+	}
+	return nil
+}
+
+func postsURL(channelID string) string {
+	return "/feed/" + channelID + "/posts"
+}
+
+/*
+GetChannelPosts returns the posts in the feed of the given channel, newest first.
+
+The function takes in five parameters:
+
+	channelID:
+		The Channel ID
+
+	oauth:
+		User oauth token.  Optional, fills in the Permissions of the posts for that user.
+
+	limit:
+		The number of posts to return.  Max is 100, default is 10.
+
+	cursor:
+		Cursor of the page to return, from a previous response.
+
+	comments:
+		The number of comments to return with each post, from 0 to 5.
+*/
+func (c *Client) GetChannelPosts(channelID string, oauth string, limit int, cursor string, comments int) (*Posts, error) {
+	return c.GetChannelPostsContext(context.Background(), channelID, oauth, limit, cursor, comments)
+}
+
+// GetChannelPostsContext is like GetChannelPosts but uses the given context for the request.
+func (c *Client) GetChannelPostsContext(ctx context.Context, channelID string, oauth string, limit int, cursor string, comments int) (*Posts, error) {
+	opts := &doOptions{
+		params: map[string]string{
+			"limit":    strconv.Itoa(pageLimit(limit, 10)),
+			"comments": strconv.Itoa(commentsLimit(comments)),
+		},
+		oauth:   oauth,
+		context: ctx,
+	}
+	if cursor != "" {
+		opts.params["cursor"] = cursor
+	}
+	posts := &Posts{}
+	if err := c.doFeed("GET", "GetChannelPosts", postsURL(channelID), opts, posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetChannelPost returns a post in the feed of the given channel with up to comments of its comments.  oauth is
+// optional, see GetChannelPosts.
+func (c *Client) GetChannelPost(channelID string, postID string, oauth string, comments int) (*Post, error) {
+	return c.GetChannelPostContext(context.Background(), channelID, postID, oauth, comments)
+}
+
+// GetChannelPostContext is like GetChannelPost but uses the given context for the request.
+func (c *Client) GetChannelPostContext(ctx context.Context, channelID string, postID string, oauth string, comments int) (*Post, error) {
+	opts := &doOptions{
+		params: map[string]string{
+			"comments": strconv.Itoa(commentsLimit(comments)),
+		},
+		oauth:   oauth,
+		context: ctx,
+	}
+	post := &Post{}
+	if err := c.doFeed("GET", "GetChannelPost", postsURL(channelID)+"/"+postID, opts, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// CreateChannelPost posts content to the feed of the given channel and returns the new post.  If share is true the
+// post is also shared on the channel's connected Twitter account.  Requires an oauth token with the
+// `channel_feed_edit` scope.
+func (c *Client) CreateChannelPost(channelID string, content string, share bool, oauth string) (*Post, error) {
+	return c.CreateChannelPostContext(context.Background(), channelID, content, share, oauth)
+}
+
+// CreateChannelPostContext is like CreateChannelPost but uses the given context for the request.
+func (c *Client) CreateChannelPostContext(ctx context.Context, channelID string, content string, share bool, oauth string) (*Post, error) {
+	opts := &doOptions{
+		params: map[string]string{
+			"share": strconv.FormatBool(share),
+		},
+		data:    feedContent{Content: content},
+		oauth:   oauth,
+		scope:   "channel_feed_edit",
+		context: ctx,
+	}
+	created := &struct {
+		Post Post `json:"post"`
+	}{}
+	if err := c.doFeed("POST", "CreateChannelPost", postsURL(channelID), opts, created); err != nil {
+		return nil, err
+	}
+	return &created.Post, nil
+}
+
+// DeleteChannelPost deletes a post from the feed of the given channel and returns it.  Requires an oauth token with
+// the `channel_feed_edit` scope.
+func (c *Client) DeleteChannelPost(channelID string, postID string, oauth string) (*Post, error) {
+	return c.DeleteChannelPostContext(context.Background(), channelID, postID, oauth)
+}
+
+// DeleteChannelPostContext is like DeleteChannelPost but uses the given context for the request.
+func (c *Client) DeleteChannelPostContext(ctx context.Context, channelID string, postID string, oauth string) (*Post, error) {
+	opts := &doOptions{
+		oauth:   oauth,
+		scope:   "channel_feed_edit",
+		context: ctx,
+	}
+	post := &Post{}
+	if err := c.doFeed("DELETE", "DeleteChannelPost", postsURL(channelID)+"/"+postID, opts, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// CreatePostReaction reacts to a post with the given emote, EndorseReaction or the ID of an emote.  Requires an oauth
+// token with the `channel_feed_edit` scope.
+func (c *Client) CreatePostReaction(channelID string, postID string, emoteID string, oauth string) (*PostReaction, error) {
+	return c.CreatePostReactionContext(context.Background(), channelID, postID, emoteID, oauth)
+}
+
+// CreatePostReactionContext is like CreatePostReaction but uses the given context for the request.
+func (c *Client) CreatePostReactionContext(ctx context.Context, channelID string, postID string, emoteID string, oauth string) (*PostReaction, error) {
+	return c.createReaction(ctx, "CreatePostReaction", postsURL(channelID)+"/"+postID+"/reactions", emoteID, oauth)
+}
+
+// DeletePostReaction removes a reaction from a post.  Requires an oauth token with the `channel_feed_edit` scope.
+func (c *Client) DeletePostReaction(channelID string, postID string, emoteID string, oauth string) error {
+	return c.DeletePostReactionContext(context.Background(), channelID, postID, emoteID, oauth)
+}
+
+// DeletePostReactionContext is like DeletePostReaction but uses the given context for the request.
+func (c *Client) DeletePostReactionContext(ctx context.Context, channelID string, postID string, emoteID string, oauth string) error {
+	return c.deleteReaction(ctx, "DeletePostReaction", postsURL(channelID)+"/"+postID+"/reactions", emoteID, oauth)
+}
+
+/*
+GetPostComments returns the comments on a post, oldest first.
+
+The function takes in four parameters:
+
+	channelID:
+		The Channel ID
+
+	postID:
+		The Post ID
+
+	limit:
+		The number of comments to return.  Max is 100, default is 10.
+
+	cursor:
+		Cursor of the page to return, from a previous response.
+*/
+func (c *Client) GetPostComments(channelID string, postID string, limit int, cursor string) (*Comments, error) {
+	return c.GetPostCommentsContext(context.Background(), channelID, postID, limit, cursor)
+}
+
+// GetPostCommentsContext is like GetPostComments but uses the given context for the request.
+func (c *Client) GetPostCommentsContext(ctx context.Context, channelID string, postID string, limit int, cursor string) (*Comments, error) {
+	opts := &doOptions{
+		params: map[string]string{
+			"limit": strconv.Itoa(pageLimit(limit, 10)),
+		},
+		context: ctx,
+	}
+	if cursor != "" {
+		opts.params["cursor"] = cursor
+	}
+	comments := &Comments{}
+	if err := c.doFeed("GET", "GetPostComments", postsURL(channelID)+"/"+postID+"/comments", opts, comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// CreatePostComment comments on a post and returns the new comment.  Requires an oauth token with the
+// `channel_feed_edit` scope.
+func (c *Client) CreatePostComment(channelID string, postID string, content string, oauth string) (*Comment, error) {
+	return c.CreatePostCommentContext(context.Background(), channelID, postID, content, oauth)
+}
+
+// CreatePostCommentContext is like CreatePostComment but uses the given context for the request.
+func (c *Client) CreatePostCommentContext(ctx context.Context, channelID string, postID string, content string, oauth string) (*Comment, error) {
+	opts := &doOptions{
+		data:    feedContent{Content: content},
+		oauth:   oauth,
+		scope:   "channel_feed_edit",
+		context: ctx,
+	}
+	comment := &Comment{}
+	if err := c.doFeed("POST", "CreatePostComment", postsURL(channelID)+"/"+postID+"/comments", opts, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeletePostComment deletes a comment on a post and returns it.  Requires an oauth token with the `channel_feed_edit`
+// scope.
+func (c *Client) DeletePostComment(channelID string, postID string, commentID string, oauth string) (*Comment, error) {
+	return c.DeletePostCommentContext(context.Background(), channelID, postID, commentID, oauth)
+}
+
+// DeletePostCommentContext is like DeletePostComment but uses the given context for the request.
+func (c *Client) DeletePostCommentContext(ctx context.Context, channelID string, postID string, commentID string, oauth string) (*Comment, error) {
+	opts := &doOptions{
+		oauth:   oauth,
+		scope:   "channel_feed_edit",
+		context: ctx,
+	}
+	comment := &Comment{}
+	if err := c.doFeed("DELETE", "DeletePostComment", postsURL(channelID)+"/"+postID+"/comments/"+commentID, opts, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// CreateCommentReaction reacts to a comment with the given emote, see CreatePostReaction.  Requires an oauth token with
+// the `channel_feed_edit` scope.
+func (c *Client) CreateCommentReaction(channelID string, postID string, commentID string, emoteID string, oauth string) (*PostReaction, error) {
+	return c.CreateCommentReactionContext(context.Background(), channelID, postID, commentID, emoteID, oauth)
+}
+
+// CreateCommentReactionContext is like CreateCommentReaction but uses the given context for the request.
+func (c *Client) CreateCommentReactionContext(ctx context.Context, channelID string, postID string, commentID string, emoteID string, oauth string) (*PostReaction, error) {
+	return c.createReaction(ctx, "CreateCommentReaction", postsURL(channelID)+"/"+postID+"/comments/"+commentID+"/reactions", emoteID, oauth)
+}
+
+// DeleteCommentReaction removes a reaction from a comment.  Requires an oauth token with the `channel_feed_edit` scope.
+func (c *Client) DeleteCommentReaction(channelID string, postID string, commentID string, emoteID string, oauth string) error {
+	return c.DeleteCommentReactionContext(context.Background(), channelID, postID, commentID, emoteID, oauth)
+}
+
+// DeleteCommentReactionContext is like DeleteCommentReaction but uses the given context for the request.
+func (c *Client) DeleteCommentReactionContext(ctx context.Context, channelID string, postID string, commentID string, emoteID string, oauth string) error {
+	return c.deleteReaction(ctx, "DeleteCommentReaction", postsURL(channelID)+"/"+postID+"/comments/"+commentID+"/reactions", emoteID, oauth)
+}
+
+func (c *Client) createReaction(ctx context.Context, name string, url string, emoteID string, oauth string) (*PostReaction, error) {
+	opts := &doOptions{
+		params: map[string]string{
+			"emote_id": emoteID,
+		},
+		oauth:   oauth,
+		scope:   "channel_feed_edit",
+		context: ctx,
+	}
+	reaction := &PostReaction{}
+	if err := c.doFeed("POST", name, url, opts, reaction); err != nil {
+		return nil, err
+	}
+	return reaction, nil
+}
+
+func (c *Client) deleteReaction(ctx context.Context, name string, url string, emoteID string, oauth string) error {
+	opts := &doOptions{
+		params: map[string]string{
+			"emote_id": emoteID,
+		},
+		oauth:   oauth,
+		scope:   "channel_feed_edit",
+		context: ctx,
+	}
+	deleted := &struct {
+		Deleted bool `json:"deleted"`
+	}{}
+	return c.doFeed("DELETE", name, url, opts, deleted)
+}
+
+// commentsLimit clamps the number of comments returned with posts to the range the API accepts, 0 to 5.
+func commentsLimit(comments int) int {
+	if comments < 0 {
+		return 0
+	}
+	if comments > 5 {
+		return 5
+	}
+	return comments
+}
@@ -0,0 +1,163 @@
+package twitch2go
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const postJSON = `{
+  "body": "Kappa post",
+  "comments": {
+    "_cursor": "1480651694954867000",
+    "_total": 1,
+    "comments": [
+      {
+        "body": "comment",
+        "created_at": "2016-12-02T04:08:14.954867Z",
+        "deleted": false,
+        "emotes": [],
+        "id": "132629",
+        "permissions": {"can_delete": false, "can_moderate": false, "can_reply": false},
+        "reactions": {},
+        "user": {"_id": "45049732", "name": "testuser", "type": "user"}
+      }
+    ]
+  },
+  "created_at": "2016-11-18T16:51:01.785357Z",
+  "deleted": false,
+  "embeds": [
+    {
+      "type": "video",
+      "request_url": "https://www.twitch.tv/chosenken/v/118979643",
+      "title": "Highlight",
+      "author_name": "Chosenken",
+      "provider_name": "Twitch"
+    }
+  ],
+  "emotes": [{"end": 4, "id": 25, "set": 0, "start": 0}],
+  "id": "443228891479487861",
+  "permissions": {"can_delete": true, "can_moderate": true, "can_reply": true},
+  "reactions": {"endorse": {"count": 2, "emote": "endorse", "user_ids": [6391593, 45049732]}},
+  "user": {"_id": "6391593", "name": "chosenken", "type": "user"}
+}`
+
+func TestGetChannelPosts(t *testing.T) {
+	jsonResponse := `{"_cursor": "1479487861147094000", "_topic": "feeds.channel.6391593", "_disabled": false, "posts": [` + postJSON + `]}`
+	var expected Posts
+	err := json.Unmarshal([]byte(jsonResponse), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	posts, err := client.GetChannelPosts("6391593", "fakeoauth", 10, "abc", 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*posts, expected) {
+		t.Errorf("GetChannelPosts: Expected %#v.  Got %#v.", expected, *posts)
+	}
+	post := posts.Posts[0]
+	if post.Reactions[EndorseReaction].Count != 2 || !post.Permissions.CanDelete || post.Emotes[0].ID != 25 || post.Embeds[0].Type != "video" || post.Comments.Total != 1 {
+		t.Errorf("Unexpected post %#v", post)
+	}
+	q := fakeRT.requests[0].URL.Query()
+	if q.Get("cursor") != "abc" || q.Get("comments") != "5" || q.Get("limit") != "10" {
+		t.Errorf("Unexpected query %s", fakeRT.requests[0].URL.RawQuery)
+	}
+}
+
+func TestCreateChannelPost(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"post": ` + postJSON + `, "tweet": ""}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	post, err := client.CreateChannelPost("6391593", "Kappa post", false, "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.ID != "443228891479487861" || post.Body != "Kappa post" {
+		t.Errorf("Unexpected post %#v", *post)
+	}
+	req := fakeRT.requests[0]
+	body, _ := ioutil.ReadAll(req.Body)
+	if req.Method != "POST" || req.URL.Path != "/kraken/feed/6391593/posts" || string(body) != `{"content":"Kappa post"}` {
+		t.Errorf("Unexpected request %s %s %s", req.Method, req.URL, body)
+	}
+}
+
+func TestDeleteChannelPost(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: postJSON, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	if _, err := client.DeleteChannelPost("6391593", "443228891479487861", "fakeoauth"); err != nil {
+		t.Fatal(err)
+	}
+	req := fakeRT.requests[0]
+	if req.Method != "DELETE" || req.URL.Path != "/kraken/feed/6391593/posts/443228891479487861" {
+		t.Errorf("Unexpected request %s %s", req.Method, req.URL)
+	}
+}
+
+func TestPostReactions(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"created_at": "2016-12-02T04:26:47.048178Z", "emote_id": "endorse", "id": "24989127", "user": {"_id": "6391593", "name": "chosenken"}}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	reaction, err := client.CreatePostReaction("6391593", "443228891479487861", EndorseReaction, "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reaction.EmoteID != EndorseReaction || reaction.User.Name != "chosenken" {
+		t.Errorf("Unexpected reaction %#v", *reaction)
+	}
+	fakeRT.message = `{"deleted": true}`
+	if err := client.DeleteCommentReaction("6391593", "443228891479487861", "132629", "25", "fakeoauth"); err != nil {
+		t.Fatal(err)
+	}
+	req := fakeRT.requests[1]
+	if req.Method != "DELETE" || req.URL.Path != "/kraken/feed/6391593/posts/443228891479487861/comments/132629/reactions" || req.URL.Query().Get("emote_id") != "25" {
+		t.Errorf("Unexpected request %s %s", req.Method, req.URL)
+	}
+}
+
+func TestPostComments(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"_cursor": "", "_total": 0, "comments": []}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	comments, err := client.GetPostComments("6391593", "443228891479487861", 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comments.Total != 0 || len(comments.Comments) != 0 {
+		t.Errorf("Unexpected comments %#v", *comments)
+	}
+	fakeRT.message = `{"body": "nice", "id": "132630", "user": {"_id": "6391593"}}`
+	comment, err := client.CreatePostComment("6391593", "443228891479487861", "nice", "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.ID != "132630" || comment.Body != "nice" {
+		t.Errorf("Unexpected comment %#v", *comment)
+	}
+	fakeRT = &FakeRoundTripper{message: `{"error":"Forbidden","status":403,"message":"Missing required scope channel_feed_edit"}`, status: http.StatusForbidden}
+	client = newTestClient(fakeRT)
+	_, err = client.DeletePostComment("6391593", "443228891479487861", "132630", "fakeoauth")
+	if !IsMissingScope(err) || !IsForbidden(err) {
+		t.Errorf("Expected a forbidden missing scope error.  Got %v.", err)
+	}
+}
+
+func TestCommentsLimit(t *testing.T) {
+	tests := []struct {
+		comments int
+		expected int
+	}{
+		{-1, 0},
+		{0, 0},
+		{5, 5},
+		{6, 5},
+	}
+	for _, test := range tests {
+		if limit := commentsLimit(test.comments); limit != test.expected {
+			t.Errorf("commentsLimit(%d): Expected %d.  Got %d.", test.comments, test.expected, limit)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package twitch2go
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+/*
+GetTopGames returns the games with the most viewers, most viewed first.
+
+The function takes in two parameters:
+
+	limit:
+		The number of games to return.  Max is 100, default is 10.
+
+	offset:
+		The offset in the list to return.
+*/
+func (c *Client) GetTopGames(limit int, offset int) (*TopGames, error) {
+	return c.GetTopGamesContext(context.Background(), limit, offset)
+}
+
+// GetTopGamesContext is like GetTopGames but uses the given context for the request.
+func (c *Client) GetTopGamesContext(ctx context.Context, limit int, offset int) (*TopGames, error) {
+	limit = pageLimit(limit, 10)
+	opts := &doOptions{
+		params: map[string]string{
+			"limit":  strconv.Itoa(limit),
+			"offset": strconv.Itoa(offset),
+		},
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("GET", "/games/top", opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetTopGames")
+	}
+	defer resp.Body.Close()
+	games := &TopGames{}
+	err = json.NewDecoder(resp.Body).Decode(games)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return games, nil
+}
+
+// SearchGames searches for games whose name matches the given query.  If live is true only games that are being
+// streamed are returned.
+func (c *Client) SearchGames(query string, live bool) ([]Game, error) {
+	return c.SearchGamesContext(context.Background(), query, live)
+}
+
+// SearchGamesContext is like SearchGames but uses the given context for the request.
+func (c *Client) SearchGamesContext(ctx context.Context, query string, live bool) ([]Game, error) {
+	opts := &doOptions{
+		params: map[string]string{
+			"query": query,
+			"live":  strconv.FormatBool(live),
+		},
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("GET", "/search/games", opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "SearchGames")
+	}
+	defer resp.Body.Close()
+	result := &GameSearchResult{}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	if result.Games == nil {
+		// Twitch sends null when nothing matches.
+		return []Game{}, nil
+	}
+	return result.Games, nil
+}
@@ -0,0 +1,100 @@
+package twitch2go
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetTopGames(t *testing.T) {
+	jsonResponse := `{
+  "_total": 1157,
+  "top": [
+    {
+      "channels": 953,
+      "viewers": 171708,
+      "game": {
+        "_id": 32399,
+        "box": {
+          "large": "https://static-cdn.jtvnw.net/ttv-boxart/Counter-Strike:%20Global%20Offensive-272x380.jpg",
+          "medium": "https://static-cdn.jtvnw.net/ttv-boxart/Counter-Strike:%20Global%20Offensive-136x190.jpg",
+          "small": "https://static-cdn.jtvnw.net/ttv-boxart/Counter-Strike:%20Global%20Offensive-52x72.jpg",
+          "template": "https://static-cdn.jtvnw.net/ttv-boxart/Counter-Strike:%20Global%20Offensive-{width}x{height}.jpg"
+        },
+        "giantbomb_id": 36113,
+        "logo": {
+          "large": "https://static-cdn.jtvnw.net/ttv-logoart/Counter-Strike:%20Global%20Offensive-240x144.jpg",
+          "medium": "https://static-cdn.jtvnw.net/ttv-logoart/Counter-Strike:%20Global%20Offensive-120x72.jpg",
+          "small": "https://static-cdn.jtvnw.net/ttv-logoart/Counter-Strike:%20Global%20Offensive-60x36.jpg",
+          "template": "https://static-cdn.jtvnw.net/ttv-logoart/Counter-Strike:%20Global%20Offensive-{width}x{height}.jpg"
+        },
+        "name": "Counter-Strike: Global Offensive",
+        "popularity": 170487
+      }
+    }
+  ]
+}`
+	var expected TopGames
+	err := json.Unmarshal([]byte(jsonResponse), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	games, err := client.GetTopGames(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*games, expected) {
+		t.Errorf("GetTopGames: Expected %#v.  Got %#v.", expected, *games)
+	}
+	game := games.Top[0].Game
+	if game.ID != "32399" || game.GiantbombID != "36113" {
+		t.Errorf("Unexpected game IDs %q and %q", game.ID, game.GiantbombID)
+	}
+	box := "https://static-cdn.jtvnw.net/ttv-boxart/Counter-Strike:%20Global%20Offensive-285x380.jpg"
+	if url := game.Box.URL(285, 380); url != box {
+		t.Errorf("Expected %#v.  Got %#v.", box, url)
+	}
+}
+
+func TestSearchGames(t *testing.T) {
+	jsonResponse := `{
+  "games": [
+    {
+      "_id": 490422,
+      "box": {
+        "template": "https://static-cdn.jtvnw.net/ttv-boxart/StarCraft%20II-{width}x{height}.jpg"
+      },
+      "giantbomb_id": 20674,
+      "localized_name": "StarCraft II",
+      "locale": "en-us",
+      "name": "StarCraft II",
+      "popularity": 2
+    }
+  ]
+}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	games, err := client.SearchGames("star", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 1 || games[0].Name != "StarCraft II" || games[0].LocalizedName != "StarCraft II" {
+		t.Errorf("Unexpected games %#v", games)
+	}
+	q := fakeRT.requests[0].URL.Query()
+	if q.Get("query") != "star" || q.Get("live") != "true" {
+		t.Errorf("Unexpected query %s", fakeRT.requests[0].URL.RawQuery)
+	}
+	fakeRT = &FakeRoundTripper{message: `{"games": null}`, status: http.StatusOK}
+	client = newTestClient(fakeRT)
+	games, err = client.SearchGames("nothing", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if games == nil || len(games) != 0 {
+		t.Errorf("Expected an empty list.  Got %#v.", games)
+	}
+}
@@ -0,0 +1,386 @@
+package twitch2go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+const helixPath = "helix"
+
+// HelixClient is a client for the Helix (new Twitch) API.  It returns the same Channel, User, Stream, Video, Follow
+// and Subscription types as Client, filling in the fields Helix provides, so code can move from Kraken endpoint by
+// endpoint.  Every HelixClient method takes a context.
+type HelixClient struct {
+	ClientID string
+	// AccessToken is the app or user access token sent as a Bearer token with every request.
+	AccessToken string
+	// TokenSource supplies the access token when AccessToken is empty.  Helix requires a token for every request.
+	TokenSource TokenSource
+	HTTPClient  *http.Client
+	// RetryPolicy controls retries of failed requests.  A nil policy disables retries.
+	RetryPolicy *RetryPolicy
+	// RateLimiter throttles requests to the API.  A nil limiter disables client side rate limiting.
+	RateLimiter *RateLimiter
+	baseURL     *url.URL
+	userAgent   string
+	headers     http.Header
+	logger      Logger
+}
+
+/*
+NewHelixClient returns a HelixClient authenticating with the given client ID and access token.  Pass an empty access
+token and set TokenSource, or use WithTokenSource, to have tokens fetched and refreshed automatically.
+
+It takes the same options as NewClient.  WithBaseURL sets the root URL the `helix` path is appended to, so a proxy or
+a local fake can be used.  WithChattersURL has no effect.
+*/
+func NewHelixClient(clientID string, accessToken string, opts ...Option) (*HelixClient, error) {
+	// The options configure a Client, whose settings are then shared with the HelixClient.
+	c, err := NewClient(clientID, opts...)
+	if err != nil {
+		return nil, errors.Annotate(err, "NewHelixClient")
+	}
+	return &HelixClient{
+		ClientID:    clientID,
+		AccessToken: accessToken,
+		TokenSource: c.TokenSource,
+		HTTPClient:  c.HTTPClient,
+		RetryPolicy: c.RetryPolicy,
+		RateLimiter: c.RateLimiter,
+		baseURL:     c.apiURL,
+		userAgent:   c.userAgent,
+		headers:     c.headers,
+		logger:      c.logger,
+	}, nil
+}
+
+// helixPagination is the pagination object of a Helix response.
+type helixPagination struct {
+	Cursor string `json:"cursor"`
+}
+
+// helixResponse is the envelope every Helix response is wrapped in.  Data is decoded into the value given to doHelix.
+type helixResponse struct {
+	Data       interface{}     `json:"data"`
+	Pagination helixPagination `json:"pagination"`
+	Total      uint            `json:"total"`
+	// TotalCost and MaxTotalCost are only returned by the EventSub endpoints.
+	TotalCost    int `json:"total_cost"`
+	MaxTotalCost int `json:"max_total_cost"`
+}
+
+// doHelix performs a GET request against the given Helix endpoint and decodes the data array of the envelope into
+// data.  It returns the envelope for its pagination cursor and total.
+func (h *HelixClient) doHelix(ctx context.Context, endpoint string, params url.Values, data interface{}) (*helixResponse, error) {
+	return h.doHelixMethod(ctx, "GET", endpoint, params, nil, data)
+}
+
+// doHelixMethod is like doHelix for any method.  body, if not nil, is sent as JSON.  data may be nil for responses
+// without a body.
+func (h *HelixClient) doHelixMethod(ctx context.Context, method string, endpoint string, params url.Values, body interface{}, data interface{}) (*helixResponse, error) {
+	u, err := h.baseURL.Parse(path.Join(helixPath, endpoint))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	u.RawQuery = params.Encode()
+	var encoded []byte
+	if body != nil {
+		encoded, err = json.Marshal(body)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	request := func(accessToken string) (*http.Response, error) {
+		newRequest := func() (*http.Request, error) {
+			var r io.Reader
+			if encoded != nil {
+				// Every attempt needs its own reader over the body.
+				r = bytes.NewReader(encoded)
+			}
+			req, err := http.NewRequest(method, u.String(), r)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for k, v := range h.headers {
+				req.Header[k] = append([]string(nil), v...)
+			}
+			if h.userAgent != "" {
+				req.Header.Set("User-Agent", h.userAgent)
+			}
+			req.Header.Set("Client-Id", h.ClientID)
+			if encoded != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if accessToken != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+			}
+			return req, nil
+		}
+		return send(ctx, h.HTTPClient, h.RetryPolicy, h.RateLimiter, h.logger, method, newRequest)
+	}
+	var resp *http.Response
+	if h.AccessToken == "" && h.TokenSource != nil {
+		resp, err = withToken(h.TokenSource, request)
+	} else {
+		resp, err = request(h.AccessToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	envelope := &helixResponse{Data: data}
+	if data == nil {
+		return envelope, nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(envelope)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return envelope, nil
+}
+
+// addAll adds every value to params under key.  Helix takes lists as repeated query parameters.
+func addAll(params url.Values, key string, values []string) {
+	for _, v := range values {
+		params.Add(key, v)
+	}
+}
+
+// addPage adds the first and after paging parameters when set.
+func addPage(params url.Values, first int, after string) {
+	if first > 0 {
+		params.Set("first", strconv.Itoa(pageLimit(first, 20)))
+	}
+	if after != "" {
+		params.Set("after", after)
+	}
+}
+
+// GetUsers returns the users with the given IDs and logins.  Up to 100 IDs and logins combined can be given.  Without
+// any, the user owning the access token is returned.
+func (h *HelixClient) GetUsers(ctx context.Context, ids []string, logins []string) ([]User, error) {
+	params := url.Values{}
+	addAll(params, "id", ids)
+	addAll(params, "login", logins)
+	data := []helixUser{}
+	_, err := h.doHelix(ctx, "users", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetUsers")
+	}
+	users := make([]User, 0, len(data))
+	for _, u := range data {
+		users = append(users, u.user())
+	}
+	return users, nil
+}
+
+// GetChannels returns the channel information of the given broadcasters.  Up to 100 broadcaster IDs can be given.
+func (h *HelixClient) GetChannels(ctx context.Context, broadcasterIDs []string) ([]Channel, error) {
+	params := url.Values{}
+	addAll(params, "broadcaster_id", broadcasterIDs)
+	data := []helixChannel{}
+	_, err := h.doHelix(ctx, "channels", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetChannels")
+	}
+	channels := make([]Channel, 0, len(data))
+	for _, ch := range data {
+		channels = append(channels, ch.channel())
+	}
+	return channels, nil
+}
+
+// HelixStreamsOptions filters the streams returned by HelixClient.GetStreams.  Empty fields are not sent.
+type HelixStreamsOptions struct {
+	UserIDs    []string
+	UserLogins []string
+	GameIDs    []string
+	Languages  []string
+	// Type is either `all` or `live`.  Default is `all`.
+	Type string
+	// First is the page size.  Maximum 100, default 20.
+	First int
+	After string
+}
+
+// GetStreams returns the live streams matching opts, most viewers first.  Pass the returned Cursor as opts.After to get
+// the next page.  Helix does not report how many streams match, so Total is always 0.
+func (h *HelixClient) GetStreams(ctx context.Context, opts HelixStreamsOptions) (*Streams, error) {
+	params := url.Values{}
+	addAll(params, "user_id", opts.UserIDs)
+	addAll(params, "user_login", opts.UserLogins)
+	addAll(params, "game_id", opts.GameIDs)
+	addAll(params, "language", opts.Languages)
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	addPage(params, opts.First, opts.After)
+	data := []helixStream{}
+	envelope, err := h.doHelix(ctx, "streams", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetStreams")
+	}
+	streams := &Streams{Cursor: envelope.Pagination.Cursor, Streams: make([]Stream, 0, len(data))}
+	for _, s := range data {
+		streams.Streams = append(streams.Streams, s.stream())
+	}
+	return streams, nil
+}
+
+// HelixVideosOptions selects the videos returned by HelixClient.GetVideos.  Exactly one of IDs, UserID and GameID must
+// be set.  Empty fields are not sent.
+type HelixVideosOptions struct {
+	IDs    []string
+	UserID string
+	GameID string
+	// Language is an ISO 639-1 code like `en`.
+	Language string
+	// Period is one of `all`, `day`, `week` and `month`.  Default is `all`.
+	Period string
+	// Sort is one of `time`, `trending` and `views`.  Default is `time`.
+	Sort string
+	// Type is one of `all`, `archive`, `highlight` and `upload`.  Default is `all`.
+	Type string
+	// First is the page size.  Maximum 100, default 20.
+	First int
+	After string
+}
+
+// GetVideos returns the videos selected by opts.  Pass the returned Cursor as opts.After to get the next page.  Helix
+// does not report how many videos match, so Total is always 0.
+func (h *HelixClient) GetVideos(ctx context.Context, opts HelixVideosOptions) (*Videos, error) {
+	params := url.Values{}
+	addAll(params, "id", opts.IDs)
+	for k, v := range map[string]string{
+		"user_id":  opts.UserID,
+		"game_id":  opts.GameID,
+		"language": opts.Language,
+		"period":   opts.Period,
+		"sort":     opts.Sort,
+		"type":     opts.Type,
+	} {
+		if v != "" {
+			params.Set(k, v)
+		}
+	}
+	addPage(params, opts.First, opts.After)
+	data := []helixVideo{}
+	envelope, err := h.doHelix(ctx, "videos", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetVideos")
+	}
+	videos := &Videos{Cursor: envelope.Pagination.Cursor, Videos: make([]Video, 0, len(data))}
+	for _, v := range data {
+		videos.Videos = append(videos.Videos, v.video())
+	}
+	return videos, nil
+}
+
+/*
+GetChannelFollowers returns the users following the given broadcaster, newest first.  Requires a user access token
+from the broadcaster or one of its moderators with the `moderator:read:followers` scope.
+
+	broadcasterID:
+		ID of the broadcaster.
+
+	userID:
+		Only return this user's follow, to check whether the user follows the broadcaster.  Optional.
+
+	first:
+		Page size.  Maximum 100, default 20.
+
+	after:
+		Cursor of the page to return, from a previous response.
+*/
+func (h *HelixClient) GetChannelFollowers(ctx context.Context, broadcasterID string, userID string, first int, after string) (*Followers, error) {
+	params := url.Values{"broadcaster_id": {broadcasterID}}
+	if userID != "" {
+		params.Set("user_id", userID)
+	}
+	addPage(params, first, after)
+	data := []helixFollower{}
+	envelope, err := h.doHelix(ctx, "channels/followers", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetChannelFollowers")
+	}
+	follows := &Followers{Total: envelope.Total, Cursor: envelope.Pagination.Cursor, Follows: make([]Follow, 0, len(data))}
+	for _, f := range data {
+		follows.Follows = append(follows.Follows, f.follow())
+	}
+	return follows, nil
+}
+
+/*
+GetFollowedChannels returns the broadcasters the given user follows, newest first.  Requires a user access token from
+that user with the `user:read:follows` scope.
+
+	userID:
+		ID of the user.
+
+	broadcasterID:
+		Only return the follow of this broadcaster, to check whether the user follows it.  Optional.
+
+	first:
+		Page size.  Maximum 100, default 20.
+
+	after:
+		Cursor of the page to return, from a previous response.
+*/
+func (h *HelixClient) GetFollowedChannels(ctx context.Context, userID string, broadcasterID string, first int, after string) (*Followers, error) {
+	params := url.Values{"user_id": {userID}}
+	if broadcasterID != "" {
+		params.Set("broadcaster_id", broadcasterID)
+	}
+	addPage(params, first, after)
+	data := []helixFollowedChannel{}
+	envelope, err := h.doHelix(ctx, "channels/followed", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetFollowedChannels")
+	}
+	follows := &Followers{Total: envelope.Total, Cursor: envelope.Pagination.Cursor, Follows: make([]Follow, 0, len(data))}
+	for _, f := range data {
+		follows.Follows = append(follows.Follows, f.follow())
+	}
+	return follows, nil
+}
+
+/*
+GetBroadcasterSubscriptions returns the subscriptions to the given broadcaster.  Requires a user access token from the
+broadcaster with the `channel:read:subscriptions` scope.
+
+	broadcasterID:
+		ID of the broadcaster.
+
+	userIDs:
+		Only return the subscriptions of these users.  Up to 100.  Optional.
+
+	first:
+		Page size.  Maximum 100, default 20.
+
+	after:
+		Cursor of the page to return, from a previous response.
+*/
+func (h *HelixClient) GetBroadcasterSubscriptions(ctx context.Context, broadcasterID string, userIDs []string, first int, after string) (*Subscribers, error) {
+	params := url.Values{"broadcaster_id": {broadcasterID}}
+	addAll(params, "user_id", userIDs)
+	addPage(params, first, after)
+	data := []helixSubscription{}
+	envelope, err := h.doHelix(ctx, "subscriptions", params, &data)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetBroadcasterSubscriptions")
+	}
+	subscribers := &Subscribers{Total: envelope.Total, Cursor: envelope.Pagination.Cursor, Subscriptions: make([]Subscription, 0, len(data))}
+	for _, s := range data {
+		subscribers.Subscriptions = append(subscribers.Subscriptions, s.subscription())
+	}
+	return subscribers, nil
+}
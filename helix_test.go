@@ -0,0 +1,246 @@
+package twitch2go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestHelixClient(rt http.RoundTripper, opts ...Option) *HelixClient {
+	opts = append([]Option{WithHTTPClient(&http.Client{Transport: rt})}, opts...)
+	client, err := NewHelixClient("fakeclientid", "fakeaccesstoken", opts...)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func TestNewHelixClientOptions(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"data": []}`, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT, WithBaseURL("http://127.0.0.1:8080/proxy"), WithUserAgent("bot/1.0"), WithHeader("X-Test", "1"))
+	if _, err := client.GetUsers(context.Background(), []string{"1"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	req := fakeRT.requests[0]
+	if u := req.URL.String(); u != "http://127.0.0.1:8080/proxy/helix/users?id=1" {
+		t.Errorf("Expected the base URL to be used.  Got %q.", u)
+	}
+	if ua, h := req.Header.Get("User-Agent"), req.Header.Get("X-Test"); ua != "bot/1.0" || h != "1" {
+		t.Errorf("Expected the user agent and header options.  Got %q and %q.", ua, h)
+	}
+	if _, err := NewHelixClient("fakeclientid", "", WithBaseURL("not a url")); err == nil {
+		t.Error("Expected an error for an invalid base URL.")
+	}
+}
+
+func TestHelixHeadersAndQuery(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"data": []}`, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT)
+	if _, err := client.GetUsers(context.Background(), []string{"1", "2"}, []string{"chosenken"}); err != nil {
+		t.Fatal(err)
+	}
+	req := fakeRT.requests[0]
+	if req.URL.Path != "/helix/users" {
+		t.Errorf("Expected path /helix/users.  Got %q.", req.URL.Path)
+	}
+	if req.URL.RawQuery != "id=1&id=2&login=chosenken" {
+		t.Errorf("Expected repeated query parameters.  Got %q.", req.URL.RawQuery)
+	}
+	if auth := req.Header.Get("Authorization"); auth != "Bearer fakeaccesstoken" {
+		t.Errorf("Expected a Bearer token.  Got %q.", auth)
+	}
+	if id := req.Header.Get("Client-Id"); id != "fakeclientid" {
+		t.Errorf("Expected the client ID header.  Got %q.", id)
+	}
+	if accept := req.Header.Get("Accept"); accept != "" {
+		t.Errorf("Expected no Kraken Accept header.  Got %q.", accept)
+	}
+}
+
+func TestHelixGetUsers(t *testing.T) {
+	jsonResponse := `{
+  "data": [{
+    "id": "141981764",
+    "login": "twitchdev",
+    "display_name": "TwitchDev",
+    "type": "",
+    "broadcaster_type": "partner",
+    "description": "Supporting third-party developers building Twitch integrations from chatbots to game integrations.",
+    "profile_image_url": "https://static-cdn.jtvnw.net/jtv_user_pictures/8a6381c7-d0c0-4576-b179-38bd5ce1d6af-profile_image-300x300.png",
+    "offline_image_url": "https://static-cdn.jtvnw.net/jtv_user_pictures/3f13ab61-ec78-4fe6-8481-8682cb3b0ac2-channel_offline_image-1920x1080.png",
+    "view_count": 5980557,
+    "email": "not-real@email.com",
+    "created_at": "2016-12-14T20:32:28Z"
+  }]
+}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT)
+	users, err := client.GetUsers(context.Background(), nil, []string{"twitchdev"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []User{{
+		ID:          "141981764",
+		Name:        "twitchdev",
+		DisplayName: "TwitchDev",
+		Type:        "user",
+		Bio:         "Supporting third-party developers building Twitch integrations from chatbots to game integrations.",
+		Logo:        "https://static-cdn.jtvnw.net/jtv_user_pictures/8a6381c7-d0c0-4576-b179-38bd5ce1d6af-profile_image-300x300.png",
+		Email:       "not-real@email.com",
+		Partnered:   true,
+		CreatedAt:   time.Date(2016, 12, 14, 20, 32, 28, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("GetUsers: Expected %#v.  Got %#v.", expected, users)
+	}
+}
+
+func TestHelixGetStreams(t *testing.T) {
+	jsonResponse := `{
+  "data": [{
+    "id": "40952121085",
+    "user_id": "101051819",
+    "user_login": "afro",
+    "user_name": "Afro",
+    "game_id": "32982",
+    "game_name": "Grand Theft Auto V",
+    "type": "live",
+    "title": "Jacob: Digital Den Laptops & Routers | NoPixel | !MAINGEAR !FCF",
+    "viewer_count": 1490,
+    "started_at": "2021-03-10T03:18:11Z",
+    "language": "en",
+    "thumbnail_url": "https://static-cdn.jtvnw.net/previews-ttv/live_user_afro-{width}x{height}.jpg",
+    "is_mature": false
+  }],
+  "pagination": {"cursor": "eyJiIjp7IkN1cnNvciI6ImV5SnpJam8zT0RNMk5TNDBORFF4TlRjMU1UY3hOU3dpWkNJNlptRnNjMlVzSW5RaU9uUnlkV1Y5In0sImEiOnsiQ3Vyc29yIjoiZXlKeklqb3hOVGs0TkM0MU56RXhNekExTVRZNU1ESXNJbVFpT21aaGJITmxMQ0owSWpwMGNuVmxmUT09In19"}
+}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT)
+	streams, err := client.GetStreams(context.Background(), HelixStreamsOptions{GameIDs: []string{"32982"}, First: 500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first := fakeRT.requests[0].URL.Query().Get("first"); first != "100" {
+		t.Errorf("Expected first to be capped at 100.  Got %q.", first)
+	}
+	if len(streams.Streams) != 1 || streams.Total != 0 || streams.Cursor == "" {
+		t.Fatalf("Expected one stream, no total and a cursor.  Got %#v.", streams)
+	}
+	s := streams.Streams[0]
+	if s.ID != "40952121085" || s.Viewers != 1490 || s.Game != "Grand Theft Auto V" || s.Channel.Name != "afro" || s.Channel.Status == "" {
+		t.Errorf("Stream not mapped correctly.  Got %#v.", s)
+	}
+}
+
+func TestHelixGetVideos(t *testing.T) {
+	jsonResponse := `{
+  "data": [{
+    "id": "335921245",
+    "stream_id": null,
+    "user_id": "141981764",
+    "user_login": "twitchdev",
+    "user_name": "TwitchDev",
+    "title": "Twitch Developers 101",
+    "description": "Welcome to Twitch development!",
+    "created_at": "2018-11-14T21:30:18Z",
+    "published_at": "2018-11-14T22:04:30Z",
+    "url": "https://www.twitch.tv/videos/335921245",
+    "thumbnail_url": "https://static-cdn.jtvnw.net/cf_vods/d2nvs31859zcd8/twitchdev/335921245/ce0f3a7f-57a3-4152-bc06-0c6610189fb3/thumb/index-0000000000-%{width}x%{height}.jpg",
+    "viewable": "public",
+    "view_count": 1863062,
+    "language": "en",
+    "type": "upload",
+    "duration": "3m21s"
+  }],
+  "pagination": {}
+}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT)
+	videos, err := client.GetVideos(context.Background(), HelixVideosOptions{UserID: "141981764"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := videos.Videos[0]
+	if v.Length != 201 || v.Views != 1863062 || v.BroadcastType != "upload" || v.Channel.Name != "twitchdev" {
+		t.Errorf("Video not mapped correctly.  Got %#v.", v)
+	}
+	if videos.Cursor != "" {
+		t.Errorf("Expected no cursor on the last page.  Got %q.", videos.Cursor)
+	}
+}
+
+func TestHelixGetChannelFollowers(t *testing.T) {
+	jsonResponse := `{
+  "total": 8,
+  "data": [{"user_id": "11111", "user_name": "UserDisplayName", "user_login": "userloginname", "followed_at": "2022-05-24T22:22:08Z"}],
+  "pagination": {"cursor": "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19"}
+}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT)
+	follows, err := client.GetChannelFollowers(context.Background(), "123456", "", 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Followers{
+		Total:  8,
+		Cursor: "eyJiIjpudWxsLCJhIjp7Ik9mZnNldCI6NX19",
+		Follows: []Follow{{
+			CreatedAt: time.Date(2022, 5, 24, 22, 22, 8, 0, time.UTC),
+			User:      User{ID: "11111", Name: "userloginname", DisplayName: "UserDisplayName", Type: "user"},
+		}},
+	}
+	if !reflect.DeepEqual(follows, expected) {
+		t.Errorf("GetChannelFollowers: Expected %#v.  Got %#v.", expected, follows)
+	}
+}
+
+func TestHelixGetBroadcasterSubscriptions(t *testing.T) {
+	jsonResponse := `{
+  "data": [{
+    "broadcaster_id": "141981764",
+    "broadcaster_login": "twitchdev",
+    "broadcaster_name": "TwitchDev",
+    "gifter_id": "12826",
+    "gifter_login": "twitch",
+    "gifter_name": "Twitch",
+    "is_gift": true,
+    "tier": "1000",
+    "plan_name": "Channel Subscription (twitchdev)",
+    "user_id": "527115020",
+    "user_name": "twitchgaming",
+    "user_login": "twitchgaming"
+  }],
+  "pagination": {"cursor": "xxxx"},
+  "total": 13,
+  "points": 13
+}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestHelixClient(fakeRT)
+	subs, err := client.GetBroadcasterSubscriptions(context.Background(), "141981764", nil, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs.Total != 13 || subs.Cursor != "xxxx" || len(subs.Subscriptions) != 1 {
+		t.Fatalf("Expected one subscription of 13.  Got %#v.", subs)
+	}
+	sub := subs.Subscriptions[0]
+	if sub.SubPlan != "1000" || !sub.IsGift || sub.User.Name != "twitchgaming" {
+		t.Errorf("Subscription not mapped correctly.  Got %#v.", sub)
+	}
+}
+
+func TestHelixError(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`, status: http.StatusUnauthorized}
+	client := newTestHelixClient(fakeRT)
+	_, err := client.GetChannels(context.Background(), []string{"141981764"})
+	if !IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error.  Got %v.", err)
+	}
+	var data json.RawMessage
+	if _, err := client.doHelix(context.Background(), "channels", nil, &data); !IsUnauthorized(err) {
+		t.Errorf("Expected doHelix to surface the API error.  Got %v.", err)
+	}
+}
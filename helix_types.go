@@ -0,0 +1,194 @@
+package twitch2go
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The helix types mirror the Helix JSON objects.  Each converts into the matching Kraken era type so that callers only
+// deal with one set of types.
+
+type helixUser struct {
+	ID              string    `json:"id"`
+	Login           string    `json:"login"`
+	DisplayName     string    `json:"display_name"`
+	Type            string    `json:"type"`
+	BroadcasterType string    `json:"broadcaster_type"`
+	Description     string    `json:"description"`
+	ProfileImageURL string    `json:"profile_image_url"`
+	OfflineImageURL string    `json:"offline_image_url"`
+	ViewCount       uint      `json:"view_count"`
+	Email           string    `json:"email"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (u helixUser) user() User {
+	userType := u.Type
+	if userType == "" {
+		userType = "user"
+	}
+	return User{
+		ID:          json.Number(u.ID),
+		Name:        u.Login,
+		DisplayName: u.DisplayName,
+		Type:        userType,
+		Bio:         u.Description,
+		Logo:        u.ProfileImageURL,
+		Email:       u.Email,
+		Partnered:   u.BroadcasterType == "partner",
+		CreatedAt:   u.CreatedAt,
+	}
+}
+
+type helixChannel struct {
+	BroadcasterID       string `json:"broadcaster_id"`
+	BroadcasterLogin    string `json:"broadcaster_login"`
+	BroadcasterName     string `json:"broadcaster_name"`
+	BroadcasterLanguage string `json:"broadcaster_language"`
+	GameID              string `json:"game_id"`
+	GameName            string `json:"game_name"`
+	Title               string `json:"title"`
+	Delay               uint   `json:"delay"`
+}
+
+func (ch helixChannel) channel() Channel {
+	return Channel{
+		ID:                  json.Number(ch.BroadcasterID),
+		Name:                ch.BroadcasterLogin,
+		DisplayName:         ch.BroadcasterName,
+		BroadcasterLanguage: ch.BroadcasterLanguage,
+		Language:            ch.BroadcasterLanguage,
+		Game:                ch.GameName,
+		Status:              ch.Title,
+		URL:                 "https://www.twitch.tv/" + ch.BroadcasterLogin,
+	}
+}
+
+type helixStream struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	UserLogin    string    `json:"user_login"`
+	UserName     string    `json:"user_name"`
+	GameID       string    `json:"game_id"`
+	GameName     string    `json:"game_name"`
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	ViewerCount  uint      `json:"viewer_count"`
+	StartedAt    time.Time `json:"started_at"`
+	Language     string    `json:"language"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	IsMature     bool      `json:"is_mature"`
+}
+
+func (s helixStream) stream() Stream {
+	return Stream{
+		ID:        json.Number(s.ID),
+		Game:      s.GameName,
+		Viewers:   s.ViewerCount,
+		CreatedAt: s.StartedAt,
+		Preview:   Preview{Template: s.ThumbnailURL},
+		Channel: Channel{
+			ID:                  json.Number(s.UserID),
+			Name:                s.UserLogin,
+			DisplayName:         s.UserName,
+			Game:                s.GameName,
+			Status:              s.Title,
+			Language:            s.Language,
+			BroadcasterLanguage: s.Language,
+			Mature:              s.IsMature,
+			URL:                 "https://www.twitch.tv/" + s.UserLogin,
+		},
+	}
+}
+
+type helixVideo struct {
+	ID           string    `json:"id"`
+	StreamID     string    `json:"stream_id"`
+	UserID       string    `json:"user_id"`
+	UserLogin    string    `json:"user_login"`
+	UserName     string    `json:"user_name"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	CreatedAt    time.Time `json:"created_at"`
+	PublishedAt  time.Time `json:"published_at"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	Viewable     string    `json:"viewable"`
+	ViewCount    uint      `json:"view_count"`
+	Language     string    `json:"language"`
+	Type         string    `json:"type"`
+	Duration     string    `json:"duration"`
+}
+
+func (v helixVideo) video() Video {
+	// Helix durations look like "3h8m33s", which time.ParseDuration understands.
+	length, _ := time.ParseDuration(v.Duration)
+	return Video{
+		ID:            v.ID,
+		BroadcastID:   json.Number(v.StreamID),
+		BroadcastType: v.Type,
+		Channel: Channel{
+			ID:          json.Number(v.UserID),
+			Name:        v.UserLogin,
+			DisplayName: v.UserName,
+		},
+		CreatedAt:   v.CreatedAt,
+		Description: v.Description,
+		Language:    v.Language,
+		Length:      uint(length.Seconds()),
+		Preview:     Preview{Template: v.ThumbnailURL},
+		PublishedAt: v.PublishedAt,
+		Title:       v.Title,
+		URL:         v.URL,
+		Viewable:    v.Viewable,
+		Views:       v.ViewCount,
+	}
+}
+
+type helixFollower struct {
+	UserID     string    `json:"user_id"`
+	UserLogin  string    `json:"user_login"`
+	UserName   string    `json:"user_name"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+func (f helixFollower) follow() Follow {
+	return Follow{
+		CreatedAt: f.FollowedAt,
+		User:      User{ID: json.Number(f.UserID), Name: f.UserLogin, DisplayName: f.UserName, Type: "user"},
+	}
+}
+
+type helixFollowedChannel struct {
+	BroadcasterID    string    `json:"broadcaster_id"`
+	BroadcasterLogin string    `json:"broadcaster_login"`
+	BroadcasterName  string    `json:"broadcaster_name"`
+	FollowedAt       time.Time `json:"followed_at"`
+}
+
+func (f helixFollowedChannel) follow() Follow {
+	return Follow{
+		CreatedAt: f.FollowedAt,
+		Channel:   Channel{ID: json.Number(f.BroadcasterID), Name: f.BroadcasterLogin, DisplayName: f.BroadcasterName},
+	}
+}
+
+type helixSubscription struct {
+	BroadcasterID string `json:"broadcaster_id"`
+	GifterID      string `json:"gifter_id"`
+	IsGift        bool   `json:"is_gift"`
+	PlanName      string `json:"plan_name"`
+	Tier          string `json:"tier"`
+	UserID        string `json:"user_id"`
+	UserLogin     string `json:"user_login"`
+	UserName      string `json:"user_name"`
+}
+
+func (s helixSubscription) subscription() Subscription {
+	return Subscription{
+		SubPlan:     s.Tier,
+		SubPlanName: s.PlanName,
+		IsGift:      s.IsGift,
+		User:        User{ID: json.Number(s.UserID), Name: s.UserLogin, DisplayName: s.UserName, Type: "user"},
+	}
+}
@@ -0,0 +1,330 @@
+package twitch2go
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+)
+
+// maxPageSize is the largest page the API returns for a single request.
+const maxPageSize = 100
+
+// pageLimit clamps a requested page size to the range the API accepts, using def for unset values.
+func pageLimit(limit int, def int) int {
+	if limit <= 0 {
+		return def
+	} else if limit > maxPageSize {
+		return maxPageSize
+	}
+	return limit
+}
+
+// pager tracks the paging state shared by the iterators.  Cursor paged endpoints use cursor, offset paged endpoints
+// use offset.
+type pager struct {
+	limit    int
+	offset   int
+	cursor   string
+	cursored bool
+	done     bool
+	err      error
+}
+
+// advance records a fetched page of n items and decides whether it was the last one.  Cursor paged endpoints can
+// return short pages before the end, so they are only done once the cursor runs out.
+func (p *pager) advance(n int, total uint, cursor string) {
+	p.offset += n
+	p.cursor = cursor
+	if p.cursored {
+		p.done = n == 0 || cursor == ""
+		return
+	}
+	switch {
+	case n == 0 || n < p.limit:
+		p.done = true
+	case total > 0 && uint(p.offset) >= total:
+		p.done = true
+	}
+}
+
+// more reports whether another page should be fetched.
+func (p *pager) more() bool {
+	return !p.done && p.err == nil
+}
+
+// FollowsIterator iterates over follows, fetching pages from the API as needed.
+//
+//	it := client.IterateChannelFollows(channelID, 100, DESC)
+//	for it.Next(ctx) {
+//		follow := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type FollowsIterator struct {
+	pager
+	fetch func(ctx context.Context, p *pager) (*Followers, error)
+	page  []Follow
+	value Follow
+}
+
+// Next advances the iterator to the next follow.  It returns false when there are no more follows or an error occurred.
+func (it *FollowsIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		follows, err := it.fetch(ctx, &it.pager)
+		if err != nil {
+			it.err = errors.Trace(err)
+			return false
+		}
+		it.page = follows.Follows
+		it.advance(len(follows.Follows), follows.Total, follows.Cursor)
+	}
+	it.value, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current follow.
+func (it *FollowsIterator) Value() Follow {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *FollowsIterator) Err() error {
+	return it.err
+}
+
+// IterateChannelFollows returns an iterator over all followers of the given channel.  limit is the page size used for
+// each request, see GetChannelFollows.
+func (c *Client) IterateChannelFollows(channelID string, limit int, direction Direction) *FollowsIterator {
+	limit = pageLimit(limit, 25)
+	return &FollowsIterator{
+		pager: pager{limit: limit, cursored: true},
+		fetch: func(ctx context.Context, p *pager) (*Followers, error) {
+			return c.GetChannelFollowsContext(ctx, channelID, p.cursor, p.limit, direction)
+		},
+	}
+}
+
+// IterateUserFollows returns an iterator over all channels the given user follows.  limit is the page size used for
+// each request, see GetUserFollows.
+func (c *Client) IterateUserFollows(userID string, limit int, direction Direction, sortBy SortBy) *FollowsIterator {
+	limit = pageLimit(limit, 25)
+	return &FollowsIterator{
+		pager: pager{limit: limit},
+		fetch: func(ctx context.Context, p *pager) (*Followers, error) {
+			return c.GetUserFollowsContext(ctx, userID, p.limit, p.offset, direction, sortBy)
+		},
+	}
+}
+
+// SubscriptionsIterator iterates over channel subscriptions, fetching pages from the API as needed.
+type SubscriptionsIterator struct {
+	pager
+	fetch func(ctx context.Context, p *pager) (*Subscribers, error)
+	page  []Subscription
+	value Subscription
+}
+
+// Next advances the iterator to the next subscription.  It returns false when there are no more subscriptions or an
+// error occurred.
+func (it *SubscriptionsIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		subscribers, err := it.fetch(ctx, &it.pager)
+		if err != nil {
+			it.err = errors.Trace(err)
+			return false
+		}
+		it.page = subscribers.Subscriptions
+		// Subscriptions are offset paged, the cursor is not used.
+		it.advance(len(subscribers.Subscriptions), subscribers.Total, "")
+	}
+	it.value, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current subscription.
+func (it *SubscriptionsIterator) Value() Subscription {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SubscriptionsIterator) Err() error {
+	return it.err
+}
+
+// IterateChannelSubscribers returns an iterator over all subscribers of the given channel.  limit is the page size used
+// for each request, see GetChannelSubscribers.
+func (c *Client) IterateChannelSubscribers(channelID string, oauth string, limit int, direction Direction) *SubscriptionsIterator {
+	limit = pageLimit(limit, 25)
+	return &SubscriptionsIterator{
+		pager: pager{limit: limit},
+		fetch: func(ctx context.Context, p *pager) (*Subscribers, error) {
+			return c.GetChannelSubscribersContext(ctx, channelID, oauth, p.limit, p.offset, direction)
+		},
+	}
+}
+
+// VideosIterator iterates over videos, fetching pages from the API as needed.
+type VideosIterator struct {
+	pager
+	fetch func(ctx context.Context, p *pager) (*Videos, error)
+	page  []Video
+	value Video
+}
+
+// Next advances the iterator to the next video.  It returns false when there are no more videos or an error occurred.
+func (it *VideosIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		videos, err := it.fetch(ctx, &it.pager)
+		if err != nil {
+			it.err = errors.Trace(err)
+			return false
+		}
+		it.page = videos.Videos
+		it.advance(len(videos.Videos), videos.Total, "")
+	}
+	it.value, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current video.
+func (it *VideosIterator) Value() Video {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *VideosIterator) Err() error {
+	return it.err
+}
+
+// IterateChannelVideos returns an iterator over all videos of the given channel.  limit is the page size used for
+// each request, see GetChannelVideos for the other parameters.
+func (c *Client) IterateChannelVideos(channelID string, limit int, broadcastType string, language string, sort VideoSort) *VideosIterator {
+	limit = pageLimit(limit, 10)
+	return &VideosIterator{
+		pager: pager{limit: limit},
+		fetch: func(ctx context.Context, p *pager) (*Videos, error) {
+			return c.GetChannelVideosContext(ctx, channelID, p.limit, p.offset, broadcastType, language, sort)
+		},
+	}
+}
+
+// TopGamesIterator iterates over the top games, fetching pages from the API as needed.
+type TopGamesIterator struct {
+	pager
+	fetch func(ctx context.Context, p *pager) (*TopGames, error)
+	page  []TopGame
+	value TopGame
+}
+
+// Next advances the iterator to the next game.  It returns false when there are no more games or an error occurred.
+func (it *TopGamesIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		games, err := it.fetch(ctx, &it.pager)
+		if err != nil {
+			it.err = errors.Trace(err)
+			return false
+		}
+		it.page = games.Top
+		it.advance(len(games.Top), games.Total, "")
+	}
+	it.value, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current game.
+func (it *TopGamesIterator) Value() TopGame {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *TopGamesIterator) Err() error {
+	return it.err
+}
+
+// IterateTopGames returns an iterator over the top games, most viewed first.  limit is the page size used for each
+// request, see GetTopGames.  Rankings change while iterating, so a game can be skipped or returned twice.
+func (c *Client) IterateTopGames(limit int) *TopGamesIterator {
+	limit = pageLimit(limit, 10)
+	return &TopGamesIterator{
+		pager: pager{limit: limit},
+		fetch: func(ctx context.Context, p *pager) (*TopGames, error) {
+			return c.GetTopGamesContext(ctx, p.limit, p.offset)
+		},
+	}
+}
+
+// ClipsIterator iterates over clips, fetching pages from the API as needed.
+type ClipsIterator struct {
+	pager
+	fetch func(ctx context.Context, p *pager) (*Clips, error)
+	page  []Clip
+	value Clip
+}
+
+// Next advances the iterator to the next clip.  It returns false when there are no more clips or an error occurred.
+func (it *ClipsIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if !it.more() {
+			return false
+		}
+		clips, err := it.fetch(ctx, &it.pager)
+		if err != nil {
+			it.err = errors.Trace(err)
+			return false
+		}
+		it.page = clips.Clips
+		// Clips are cursor paged and have no total.
+		it.advance(len(clips.Clips), 0, clips.Cursor)
+	}
+	it.value, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current clip.
+func (it *ClipsIterator) Value() Clip {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ClipsIterator) Err() error {
+	return it.err
+}
+
+// IterateTopClips returns an iterator over all top clips selected by opts, starting at opts.Cursor.  opts.Limit is the
+// page size used for each request, see GetTopClips.
+func (c *Client) IterateTopClips(opts TopClipsOptions) *ClipsIterator {
+	opts.Limit = pageLimit(opts.Limit, 10)
+	return &ClipsIterator{
+		pager: pager{limit: opts.Limit, cursor: opts.Cursor, cursored: true},
+		fetch: func(ctx context.Context, p *pager) (*Clips, error) {
+			opts.Cursor = p.cursor
+			return c.GetTopClipsContext(ctx, opts)
+		},
+	}
+}
+
+// IterateFollowedClips returns an iterator over all top clips of the games the user follows.  limit is the page size
+// used for each request, see GetFollowedClips.
+func (c *Client) IterateFollowedClips(oauth string, limit int, trending bool) *ClipsIterator {
+	limit = pageLimit(limit, 10)
+	return &ClipsIterator{
+		pager: pager{limit: limit, cursored: true},
+		fetch: func(ctx context.Context, p *pager) (*Clips, error) {
+			return c.GetFollowedClipsContext(ctx, oauth, p.limit, p.cursor, trending)
+		},
+	}
+}
@@ -0,0 +1,87 @@
+//go:build go1.23
+
+package twitch2go
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns a range-over-func sequence of the remaining follows.  If the iteration stops because of an error, the
+// error is yielded as the last element with a zero Follow.
+//
+//	for follow, err := range client.IterateChannelFollows(channelID, 100, DESC).All(ctx) {
+//		...
+//	}
+func (it *FollowsIterator) All(ctx context.Context) iter.Seq2[Follow, error] {
+	return func(yield func(Follow, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(Follow{}, err)
+		}
+	}
+}
+
+// All returns a range-over-func sequence of the remaining subscriptions.  If the iteration stops because of an error,
+// the error is yielded as the last element with a zero Subscription.
+func (it *SubscriptionsIterator) All(ctx context.Context) iter.Seq2[Subscription, error] {
+	return func(yield func(Subscription, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(Subscription{}, err)
+		}
+	}
+}
+
+// All returns a range-over-func sequence of the remaining videos.  If the iteration stops because of an error, the
+// error is yielded as the last element with a zero Video.
+func (it *VideosIterator) All(ctx context.Context) iter.Seq2[Video, error] {
+	return func(yield func(Video, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(Video{}, err)
+		}
+	}
+}
+
+// All returns a range-over-func sequence of the remaining top games.  If the iteration stops because of an error, the
+// error is yielded as the last element with a zero TopGame.
+func (it *TopGamesIterator) All(ctx context.Context) iter.Seq2[TopGame, error] {
+	return func(yield func(TopGame, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(TopGame{}, err)
+		}
+	}
+}
+
+// All returns a range-over-func sequence of the remaining clips.  If the iteration stops because of an error, the error
+// is yielded as the last element with a zero Clip.
+func (it *ClipsIterator) All(ctx context.Context) iter.Seq2[Clip, error] {
+	return func(yield func(Clip, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(Clip{}, err)
+		}
+	}
+}
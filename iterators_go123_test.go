@@ -0,0 +1,44 @@
+//go:build go1.23
+
+package twitch2go
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFollowsIteratorAll(t *testing.T) {
+	rt := &pagingRoundTripper{total: 120, cursored: true}
+	client := newTestClient(rt)
+	n := 0
+	for follow, err := range client.IterateChannelFollows("69222531", 100, DESC).All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if follow.User.ID.String() == "" {
+			t.Fatal("Expected a follow with a user.")
+		}
+		n++
+		if n == 110 {
+			break
+		}
+	}
+	if n != 110 {
+		t.Errorf("Expected to stop after 110 follows.  Got %d.", n)
+	}
+	if len(rt.limits) != 2 {
+		t.Errorf("Expected 2 requests.  Got %d.", len(rt.limits))
+	}
+}
+
+func TestFollowsIteratorAllError(t *testing.T) {
+	rt := &pagingRoundTripper{total: 100, failAt: 1}
+	client := newTestClient(rt)
+	var last error
+	for _, err := range client.IterateUserFollows("123456", 25, ASC, CreatedAt).All(context.Background()) {
+		last = err
+	}
+	if !IsNotFound(last) {
+		t.Errorf("Expected a not found error.  Got %v.", last)
+	}
+}
@@ -0,0 +1,168 @@
+package twitch2go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// pagingRoundTripper serves total items in pages, honoring the limit, offset and cursor query parameters.  The cursor
+// is the string form of the next offset.  The page of request shortAt only holds half the limit.
+type pagingRoundTripper struct {
+	total    int
+	cursored bool
+	limits   []int
+	failAt   int
+	shortAt  int
+}
+
+func (rt *pagingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	rt.limits = append(rt.limits, limit)
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	if rt.cursored {
+		offset, _ = strconv.Atoi(q.Get("cursor"))
+	}
+	status := http.StatusOK
+	var body []byte
+	if rt.failAt > 0 && len(rt.limits) == rt.failAt {
+		status = http.StatusNotFound
+		body = []byte(`{"error":"Not Found","status":404,"message":"gone"}`)
+	} else {
+		if rt.shortAt > 0 && len(rt.limits) == rt.shortAt {
+			limit /= 2
+		}
+		followers := Followers{Total: uint(rt.total)}
+		for i := offset; i < offset+limit && i < rt.total; i++ {
+			followers.Follows = append(followers.Follows, Follow{User: User{ID: json.Number(strconv.Itoa(i))}})
+		}
+		if rt.cursored && offset+limit < rt.total {
+			followers.Cursor = strconv.Itoa(offset + limit)
+		}
+		body, _ = json.Marshal(followers)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func collectFollows(it *FollowsIterator) []string {
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().User.ID.String())
+	}
+	return ids
+}
+
+func TestIterateChannelFollows(t *testing.T) {
+	rt := &pagingRoundTripper{total: 250, cursored: true}
+	client := newTestClient(rt)
+	it := client.IterateChannelFollows("69222531", 500, DESC)
+	ids := collectFollows(it)
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 250 || ids[0] != "0" || ids[249] != "249" {
+		t.Errorf("Expected follows 0 to 249.  Got %d follows.", len(ids))
+	}
+	if fmt.Sprint(rt.limits) != "[100 100 100]" {
+		t.Errorf("Expected three requests of 100.  Got %v.", rt.limits)
+	}
+}
+
+func TestIterateChannelFollowsShortPage(t *testing.T) {
+	rt := &pagingRoundTripper{total: 250, cursored: true, shortAt: 1}
+	client := newTestClient(rt)
+	it := client.IterateChannelFollows("69222531", 100, DESC)
+	ids := collectFollows(it)
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 250 || ids[249] != "249" {
+		t.Errorf("Expected follows 0 to 249 past the short page.  Got %d follows.", len(ids))
+	}
+	if fmt.Sprint(rt.limits) != "[100 100 100]" {
+		t.Errorf("Expected three requests of 100.  Got %v.", rt.limits)
+	}
+}
+
+func TestIterateUserFollowsStopsAtTotal(t *testing.T) {
+	rt := &pagingRoundTripper{total: 50}
+	client := newTestClient(rt)
+	it := client.IterateUserFollows("123456", 25, ASC, CreatedAt)
+	ids := collectFollows(it)
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 50 {
+		t.Errorf("Expected 50 follows.  Got %d.", len(ids))
+	}
+	if len(rt.limits) != 2 {
+		t.Errorf("Expected 2 requests.  Got %d.", len(rt.limits))
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	rt := &pagingRoundTripper{total: 100, failAt: 2}
+	client := newTestClient(rt)
+	it := client.IterateUserFollows("123456", 25, ASC, CreatedAt)
+	ids := collectFollows(it)
+	if len(ids) != 25 {
+		t.Errorf("Expected the 25 follows of the first page.  Got %d.", len(ids))
+	}
+	if !IsNotFound(it.Err()) {
+		t.Errorf("Expected a not found error.  Got %v.", it.Err())
+	}
+	if it.Next(context.Background()) {
+		t.Error("Expected Next to keep returning false after an error.")
+	}
+}
+
+func TestIterateChannelVideos(t *testing.T) {
+	page := `{"_total": 3, "videos": [{"_id": "v1"}, {"_id": "v2"}, {"_id": "v3"}]}`
+	fakeRT := &FakeRoundTripper{message: page, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	it := client.IterateChannelVideos("123456", 10, "", "", Time)
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[v1 v2 v3]" {
+		t.Errorf("Expected [v1 v2 v3].  Got %v.", ids)
+	}
+	if len(fakeRT.requests) != 1 {
+		t.Errorf("Expected a single request for a short page.  Got %d.", len(fakeRT.requests))
+	}
+}
+
+func TestIterateTopGames(t *testing.T) {
+	page := `{"_total": 2, "top": [{"viewers": 20, "game": {"name": "a"}}, {"viewers": 10, "game": {"name": "b"}}]}`
+	fakeRT := &FakeRoundTripper{message: page, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	it := client.IterateTopGames(2)
+	var names []string
+	for it.Next(context.Background()) {
+		names = append(names, it.Value().Game.Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names) != "[a b]" {
+		t.Errorf("Expected [a b].  Got %v.", names)
+	}
+	if len(fakeRT.requests) != 1 {
+		t.Errorf("Expected the total to end the iteration after one request.  Got %d.", len(fakeRT.requests))
+	}
+}
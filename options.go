@@ -0,0 +1,137 @@
+package twitch2go
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Logger receives the Client's diagnostic messages, such as retried requests.  *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Option configures a Client created by NewClient, or a HelixClient created by NewHelixClient.
+type Option func(c *Client) error
+
+// WithBaseURL sets the root URL of the API, `https://api.twitch.tv` by default.  The `kraken` path, or `helix` for a
+// HelixClient, is appended to it, so the URL of a proxy or a local fake can be given as is.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := parseBaseURL(baseURL)
+		if err != nil {
+			return errors.Annotate(err, "WithBaseURL")
+		}
+		c.apiURL = u
+		return nil
+	}
+}
+
+// WithChattersURL sets the URL of the chatters endpoint.  It must contain a single `%s` verb, which is replaced by the
+// channel name.  The default is ChatterEndpoint.
+func WithChattersURL(chattersURL string) Option {
+	return func(c *Client) error {
+		if strings.Count(chattersURL, "%s") != 1 {
+			return errors.Errorf("WithChattersURL: %q must contain exactly one %%s", chattersURL)
+		}
+		if _, err := url.Parse(strings.Replace(chattersURL, "%s", "channel", 1)); err != nil {
+			return errors.Annotate(err, "WithChattersURL")
+		}
+		c.chattersURL = chattersURL
+		return nil
+	}
+}
+
+// WithHTTPClient sets the http.Client used for every request.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("WithHTTPClient: nil http.Client")
+		}
+		c.HTTPClient = httpClient
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithLogger sets the Logger the Client reports retried requests to.  By default nothing is logged.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every attempt of a request.  It applies to the http.Client in use once all options
+// are applied, without modifying an http.Client given with WithHTTPClient.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return errors.Errorf("WithTimeout: negative timeout %s", timeout)
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithHeader adds a header sent with every request.  Headers set by the Client itself, like Client-ID, and per request
+// headers take precedence.
+func WithHeader(key string, value string) Option {
+	return func(c *Client) error {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the RetryPolicy of the Client.  A nil policy disables retries.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter sets the RateLimiter of the Client.  A nil limiter disables client side rate limiting.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithTokenSource sets the TokenSource of the Client.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) error {
+		c.TokenSource = source
+		return nil
+	}
+}
+
+// parseBaseURL parses an absolute base URL and makes sure its path ends with a slash, so relative paths resolve below
+// it.
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.Errorf("%q is not an absolute URL", baseURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
@@ -0,0 +1,87 @@
+package twitch2go
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{}`, status: http.StatusOK}
+	client := newTestClient(fakeRT,
+		WithBaseURL("https://twitch-proxy.internal/twitch"),
+		WithChattersURL("https://tmi-proxy.internal/chatters/%s"),
+		WithUserAgent("twitch2go-test/1.0"),
+		WithHeader("X-Team", "ops"),
+		WithTimeout(5*time.Second),
+	)
+	if _, err := client.GetChannelByID("6391593"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetChatters("chosenken"); err != nil {
+		t.Fatal(err)
+	}
+	api, chatters := fakeRT.requests[0], fakeRT.requests[1]
+	if u := api.URL.String(); u != "https://twitch-proxy.internal/twitch/kraken/channels/6391593" {
+		t.Errorf("Unexpected API URL %q.", u)
+	}
+	if u := chatters.URL.String(); u != "https://tmi-proxy.internal/chatters/chosenken" {
+		t.Errorf("Unexpected chatters URL %q.", u)
+	}
+	for _, req := range fakeRT.requests {
+		if ua := req.Header.Get("User-Agent"); ua != "twitch2go-test/1.0" {
+			t.Errorf("Expected the user agent.  Got %q.", ua)
+		}
+		if team := req.Header.Get("X-Team"); team != "ops" {
+			t.Errorf("Expected the default header.  Got %q.", team)
+		}
+	}
+	if client.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("Expected a 5s timeout.  Got %s.", client.HTTPClient.Timeout)
+	}
+}
+
+func TestNewClientTimeoutKeepsCallerClient(t *testing.T) {
+	httpClient := &http.Client{}
+	client, err := NewClient("fakeclientid", WithHTTPClient(httpClient), WithTimeout(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if httpClient.Timeout != 0 || client.HTTPClient.Timeout != time.Second {
+		t.Errorf("Expected the timeout on a copy of the given http.Client.")
+	}
+}
+
+func TestNewClientInvalidOptions(t *testing.T) {
+	for name, opt := range map[string]Option{
+		"relative base URL":   WithBaseURL("/kraken"),
+		"malformed base URL":  WithBaseURL("http://[::1"),
+		"chatters URL verb":   WithChattersURL("https://tmi.twitch.tv/chatters"),
+		"nil http client":     WithHTTPClient(nil),
+		"negative timeout":    WithTimeout(-time.Second),
+		"double chatters URL": WithChattersURL("https://%s/%s"),
+	} {
+		if _, err := NewClient("fakeclientid", opt); err == nil {
+			t.Errorf("%s: Expected an error.", name)
+		}
+	}
+}
+
+func TestLoggerReportsRetries(t *testing.T) {
+	var buf bytes.Buffer
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{status: http.StatusServiceUnavailable, body: "unavailable"},
+		{status: http.StatusOK, body: `{}`},
+	}}
+	client := newRetryTestClient(rt, 2)
+	client.logger = log.New(&buf, "", 0)
+	if _, err := client.GetChannelByID("6391593"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "retrying GET https://api.twitch.tv/kraken/channels/6391593") || !strings.Contains(buf.String(), "503") {
+		t.Errorf("Expected the retry to be logged.  Got %q.", buf.String())
+	}
+}
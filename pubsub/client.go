@@ -0,0 +1,456 @@
+// Package pubsub is a client for Twitch PubSub, the WebSocket service pushing realtime channel events like cheers,
+// subscriptions and channel points redemptions.
+//
+//	client := pubsub.NewClient(oauth)
+//	client.OnBits = func(e pubsub.BitsEvent) {
+//		fmt.Printf("%s cheered %d bits\n", e.UserName, e.BitsUsed)
+//	}
+//	client.Listen(pubsub.BitsTopic(channelID))
+//	err := client.Run(ctx)
+package pubsub
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// DefaultAddress is the Twitch PubSub server.
+	DefaultAddress = "wss://pubsub-edge.twitch.tv"
+	// DefaultPingInterval is how often the client pings the server, if PingInterval is not set.  Twitch closes
+	// connections that have not sent a PING in 5 minutes.
+	DefaultPingInterval = 4 * time.Minute
+	// DefaultPongTimeout is how long the client waits for a PONG, if PongTimeout is not set.
+	DefaultPongTimeout = 10 * time.Second
+	// DefaultReconnectDelay is the first delay before reconnecting, if ReconnectDelay is not set.
+	DefaultReconnectDelay = time.Second
+	// DefaultMaxReconnectDelay is the longest delay before reconnecting, if MaxReconnectDelay is not set.
+	DefaultMaxReconnectDelay = 2 * time.Minute
+)
+
+// errReconnect is returned when the server asks the client to reconnect.
+var errReconnect = errors.New("pubsub: server asked to reconnect")
+
+// ListenError is reported to OnError when the server refuses to LISTEN to topics, usually because the oauth token is
+// invalid or lacks a scope.  The topics are dropped from the client.
+type ListenError struct {
+	Topics []string
+	// Err is the error returned by Twitch, like `ERR_BADAUTH`.
+	Err string
+}
+
+func (e *ListenError) Error() string {
+	return fmt.Sprintf("pubsub: listening to %v: %s", e.Topics, e.Err)
+}
+
+// frame is a message exchanged with the server.
+type frame struct {
+	Type  string     `json:"type"`
+	Nonce string     `json:"nonce,omitempty"`
+	Error string     `json:"error,omitempty"`
+	Data  *frameData `json:"data,omitempty"`
+}
+
+type frameData struct {
+	Topics    []string `json:"topics,omitempty"`
+	AuthToken string   `json:"auth_token,omitempty"`
+	Topic     string   `json:"topic,omitempty"`
+	Message   string   `json:"message,omitempty"`
+}
+
+// Client is a Twitch PubSub client.  Set the handlers before calling Run; they are called one at a time from the
+// goroutine running Run.  Listen and Unlisten can be called at any time.
+type Client struct {
+	// Address is the PubSub server, a ws:// or wss:// URL.  Default is DefaultAddress.
+	Address string
+	// OAuth is the oauth token sent with every LISTEN.  It needs the scopes of the topics listened to.
+	OAuth string
+	// TLSConfig configures wss:// connections.  nil uses the defaults.
+	TLSConfig *tls.Config
+	// PingInterval is how often the client pings the server.  Each ping comes up to a tenth of the interval early, so
+	// that clients started together do not ping together.  Default is DefaultPingInterval.
+	PingInterval time.Duration
+	// PongTimeout is how long the server has to answer a ping before the client reconnects.  Default is
+	// DefaultPongTimeout.
+	PongTimeout time.Duration
+	// ReconnectDelay and MaxReconnectDelay bound the delay before reconnecting, which doubles with every failed
+	// attempt.  Defaults are DefaultReconnectDelay and DefaultMaxReconnectDelay.
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+
+	// OnConnect is called every time the client has connected and sent its LISTEN.
+	OnConnect func()
+	// OnMessage is called with the raw JSON of every message received, before the typed handlers.
+	OnMessage         func(topic string, message []byte)
+	OnBits            func(e BitsEvent)
+	OnSubscribe       func(e SubscribeEvent)
+	OnRedemption      func(r Redemption)
+	OnWhisper         func(w Whisper)
+	OnModeratorAction func(a ModeratorAction)
+	// OnError is called with the errors that do not stop the client, like a *ListenError or a message that could not
+	// be decoded.
+	OnError func(err error)
+
+	mu      sync.Mutex
+	ws      *websocket.Conn
+	topics  map[string]bool
+	pending map[string][]string
+	nonce   int
+}
+
+// NewClient returns a Client listening with the given oauth token.
+func NewClient(oauth string) *Client {
+	return &Client{
+		OAuth:   oauth,
+		topics:  map[string]bool{},
+		pending: map[string][]string{},
+	}
+}
+
+// Listen subscribes to the given topics.  Topics listened to before Run are subscribed once connected, and all topics
+// are subscribed again after a reconnect.  Refused topics are reported to OnError.
+func (c *Client) Listen(topics ...string) error {
+	c.mu.Lock()
+	var added []string
+	for _, topic := range topics {
+		if !c.topics[topic] {
+			c.topics[topic] = true
+			added = append(added, topic)
+		}
+	}
+	ws := c.ws
+	c.mu.Unlock()
+	if ws == nil || len(added) == 0 {
+		return nil
+	}
+	return c.send(ws, "LISTEN", added)
+}
+
+// Unlisten unsubscribes from the given topics.
+func (c *Client) Unlisten(topics ...string) error {
+	c.mu.Lock()
+	var removed []string
+	for _, topic := range topics {
+		if c.topics[topic] {
+			delete(c.topics, topic)
+			removed = append(removed, topic)
+		}
+	}
+	ws := c.ws
+	c.mu.Unlock()
+	if ws == nil || len(removed) == 0 {
+		return nil
+	}
+	return c.send(ws, "UNLISTEN", removed)
+}
+
+// Topics returns the topics listened to, sorted.
+func (c *Client) Topics() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// send sends a LISTEN or UNLISTEN for topics on ws.  The topics of a LISTEN are kept by nonce until the server
+// answers.
+func (c *Client) send(ws *websocket.Conn, typ string, topics []string) error {
+	c.mu.Lock()
+	c.nonce++
+	nonce := strconv.Itoa(c.nonce)
+	if typ == "LISTEN" {
+		c.pending[nonce] = topics
+	}
+	c.mu.Unlock()
+	f := frame{
+		Type:  typ,
+		Nonce: nonce,
+		Data:  &frameData{Topics: topics, AuthToken: c.OAuth},
+	}
+	return errors.Trace(websocket.JSON.Send(ws, f))
+}
+
+/*
+Run connects to the PubSub server and handles messages until ctx is done.  When the connection is lost, the server does
+not answer a ping, or the server asks for it, Run reconnects and listens to the topics again.
+
+Run returns ctx.Err() once ctx is done.
+*/
+func (c *Client) Run(ctx context.Context) error {
+	var delay time.Duration
+	for {
+		connected, err := c.connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		switch {
+		case errors.Cause(err) == errReconnect:
+			delay = 0
+		case connected || delay == 0:
+			delay = c.reconnectDelay()
+		default:
+			delay *= 2
+			if max := c.maxReconnectDelay(); delay > max {
+				delay = max
+			}
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// connect runs a single connection until it fails.  It reports whether the client got connected.
+func (c *Client) connect(ctx context.Context) (bool, error) {
+	address := c.Address
+	if address == "" {
+		address = DefaultAddress
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	config, err := websocket.NewConfig(address, "http://"+u.Host)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	config.TlsConfig = c.TLSConfig
+	ws, err := config.DialContext(ctx)
+	if err != nil {
+		return false, errors.Annotate(err, "pubsub: connecting")
+	}
+	pong := make(chan struct{}, 1)
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		ws.Close()
+		c.mu.Lock()
+		c.ws = nil
+		c.pending = map[string][]string{}
+		c.mu.Unlock()
+	}()
+
+	c.mu.Lock()
+	c.ws = ws
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	c.mu.Unlock()
+	if len(topics) > 0 {
+		sort.Strings(topics)
+		if err := c.send(ws, "LISTEN", topics); err != nil {
+			return true, err
+		}
+	}
+	go c.ping(ctx, ws, pong, done)
+	if c.OnConnect != nil {
+		c.OnConnect()
+	}
+	for {
+		var f frame
+		if err := websocket.JSON.Receive(ws, &f); err != nil {
+			return true, errors.Trace(err)
+		}
+		if err := c.handle(f, pong); err != nil {
+			return true, err
+		}
+	}
+}
+
+// ping pings the server every PingInterval and closes the connection when the server does not answer, or when ctx is
+// done.
+func (c *Client) ping(ctx context.Context, ws *websocket.Conn, pong <-chan struct{}, done <-chan struct{}) {
+	interval := c.PingInterval
+	if interval <= 0 {
+		interval = DefaultPingInterval
+	}
+	timeout := c.PongTimeout
+	if timeout <= 0 {
+		timeout = DefaultPongTimeout
+	}
+	for {
+		if !wait(ctx, ws, done, nil, interval-time.Duration(rand.Int63n(int64(interval)/10+1))) {
+			return
+		}
+		select {
+		case <-pong:
+		default:
+		}
+		if err := websocket.JSON.Send(ws, frame{Type: "PING"}); err != nil {
+			ws.Close()
+			return
+		}
+		if wait(ctx, ws, done, pong, timeout) {
+			// The server did not answer the ping, the connection is dead.
+			ws.Close()
+			return
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
+
+// wait waits for d, done, ctx or a pong and reports whether d elapsed.  ws is closed when ctx is done.
+func wait(ctx context.Context, ws *websocket.Conn, done <-chan struct{}, pong <-chan struct{}, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		ws.Close()
+	case <-pong:
+	case <-t.C:
+		return true
+	}
+	return false
+}
+
+// handle processes a frame received from the server.
+func (c *Client) handle(f frame, pong chan<- struct{}) error {
+	switch f.Type {
+	case "PONG":
+		select {
+		case pong <- struct{}{}:
+		default:
+		}
+	case "RECONNECT":
+		return errReconnect
+	case "RESPONSE":
+		c.mu.Lock()
+		topics := c.pending[f.Nonce]
+		delete(c.pending, f.Nonce)
+		if f.Error != "" {
+			for _, topic := range topics {
+				delete(c.topics, topic)
+			}
+		}
+		c.mu.Unlock()
+		if f.Error != "" {
+			c.error(&ListenError{Topics: topics, Err: f.Error})
+		}
+	case "MESSAGE":
+		if f.Data == nil {
+			return nil
+		}
+		if err := c.dispatch(f.Data.Topic, []byte(f.Data.Message)); err != nil {
+			c.error(errors.Annotatef(err, "pubsub: decoding message on %s", f.Data.Topic))
+		}
+	}
+	return nil
+}
+
+// dispatch decodes a message and calls the handler of its topic.
+func (c *Client) dispatch(topic string, message []byte) error {
+	if c.OnMessage != nil {
+		c.OnMessage(topic, message)
+	}
+	switch topicPrefix(topic) {
+	case bitsPrefix:
+		if c.OnBits == nil {
+			return nil
+		}
+		e, err := decodeBits(message)
+		if err != nil {
+			return err
+		}
+		c.OnBits(e)
+	case subscribePrefix:
+		if c.OnSubscribe == nil {
+			return nil
+		}
+		e := SubscribeEvent{}
+		if err := json.Unmarshal(message, &e); err != nil {
+			return err
+		}
+		c.OnSubscribe(e)
+	case channelPointsPrefix:
+		if c.OnRedemption == nil {
+			return nil
+		}
+		m := channelPointsMessage{}
+		if err := json.Unmarshal(message, &m); err != nil {
+			return err
+		}
+		if m.Type == "reward-redeemed" {
+			c.OnRedemption(m.Data.Redemption)
+		}
+	case whispersPrefix:
+		if c.OnWhisper == nil {
+			return nil
+		}
+		m := whisperMessage{}
+		if err := json.Unmarshal(message, &m); err != nil {
+			return err
+		}
+		if m.Type == "whisper_received" {
+			c.OnWhisper(m.DataObject)
+		}
+	case moderatorActionsPrefix:
+		if c.OnModeratorAction == nil {
+			return nil
+		}
+		m := moderatorActionMessage{}
+		if err := json.Unmarshal(message, &m); err != nil {
+			return err
+		}
+		// The channel ID is the last part of the topic.
+		m.Data.ChannelID = topic[strings.LastIndexByte(topic, '.')+1:]
+		c.OnModeratorAction(m.Data)
+	}
+	return nil
+}
+
+func (c *Client) error(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+	}
+}
+
+func (c *Client) reconnectDelay() time.Duration {
+	if c.ReconnectDelay > 0 {
+		return c.ReconnectDelay
+	}
+	return DefaultReconnectDelay
+}
+
+func (c *Client) maxReconnectDelay() time.Duration {
+	if c.MaxReconnectDelay > 0 {
+		return c.MaxReconnectDelay
+	}
+	return DefaultMaxReconnectDelay
+}
+
+// sleep waits for d or until ctx is done, returning ctx.Err() in that case.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
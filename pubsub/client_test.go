@@ -0,0 +1,174 @@
+package pubsub_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kenXengineering/twitch2go/pubsub"
+	"github.com/kenXengineering/twitch2go/twitchtest"
+)
+
+const waitTimeout = 5 * time.Second
+
+// runClient runs client until the test ends.
+func runClient(t *testing.T, client *pubsub.Client) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(waitTimeout):
+			t.Error("Run did not return after the context was canceled.")
+		}
+	})
+}
+
+func waitForListen(t *testing.T, srv *twitchtest.PubSubServer, topic string) {
+	t.Helper()
+	if err := srv.WaitForListen(topic, waitTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientEvents(t *testing.T) {
+	srv := twitchtest.NewPubSubServer()
+	defer srv.Close()
+	client := pubsub.NewClient("token")
+	client.Address = srv.URL()
+	events := make(chan interface{}, 5)
+	client.OnBits = func(e pubsub.BitsEvent) { events <- e }
+	client.OnSubscribe = func(e pubsub.SubscribeEvent) { events <- e }
+	client.OnRedemption = func(r pubsub.Redemption) { events <- r }
+	client.OnWhisper = func(w pubsub.Whisper) { events <- w }
+	client.OnModeratorAction = func(a pubsub.ModeratorAction) { events <- a }
+	client.OnError = func(err error) { t.Errorf("Unexpected error: %v", err) }
+	topics := []string{
+		pubsub.BitsTopic("1"),
+		pubsub.SubscribeTopic("1"),
+		pubsub.ChannelPointsTopic("1"),
+		pubsub.WhispersTopic("2"),
+		pubsub.ModeratorActionsTopic("2", "1"),
+	}
+	client.Listen(topics...)
+	runClient(t, client)
+	for _, topic := range topics {
+		waitForListen(t, srv, topic)
+	}
+
+	srv.Publish(pubsub.BitsTopic("1"), `{"data":{"user_name":"ronni","channel_id":"1","bits_used":100},"message_id":"m1"}`)
+	srv.Publish(pubsub.SubscribeTopic("1"), `{"user_name":"ronni","channel_id":"1","context":"sub","sub_plan":"Prime"}`)
+	srv.Publish(pubsub.ChannelPointsTopic("1"), `{"type":"reward-redeemed","data":{"redemption":{"id":"r1","user":{"login":"ronni"},"reward":{"title":"Hydrate","cost":500},"status":"UNFULFILLED"}}}`)
+	srv.Publish(pubsub.WhispersTopic("2"), `{"type":"whisper_received","data":"{}","data_object":{"message_id":"w1","body":"psst","from_id":3,"tags":{"login":"ronni"}}}`)
+	srv.Publish(pubsub.ModeratorActionsTopic("2", "1"), `{"type":"moderation_action","data":{"type":"chat_login_moderation","moderation_action":"ban","args":["spammer","spam"],"created_by":"mod"}}`)
+
+	expected := []interface{}{
+		pubsub.BitsEvent{MessageID: "m1", UserName: "ronni", ChannelID: "1", BitsUsed: 100},
+		pubsub.SubscribeEvent{UserName: "ronni", ChannelID: "1", Context: pubsub.SubContext, SubPlan: "Prime"},
+		pubsub.Redemption{ID: "r1", User: pubsub.RedemptionUser{Login: "ronni"}, Reward: pubsub.Reward{Title: "Hydrate", Cost: 500}, Status: "UNFULFILLED"},
+		pubsub.Whisper{MessageID: "w1", Body: "psst", FromID: "3", Tags: pubsub.WhisperTags{Login: "ronni"}},
+		pubsub.ModeratorAction{ChannelID: "1", Type: "chat_login_moderation", ModerationAction: "ban", Args: []string{"spammer", "spam"}, CreatedBy: "mod"},
+	}
+	for _, e := range expected {
+		select {
+		case got := <-events:
+			if !reflect.DeepEqual(got, e) {
+				t.Errorf("Expected %#v.  Got %#v.", e, got)
+			}
+		case <-time.After(waitTimeout):
+			t.Fatalf("Expected %#v.  Got nothing.", e)
+		}
+	}
+}
+
+func TestClientBadAuth(t *testing.T) {
+	srv := twitchtest.NewPubSubServer()
+	defer srv.Close()
+	srv.AddToken("good")
+	client := pubsub.NewClient("bad")
+	client.Address = srv.URL()
+	errs := make(chan error, 1)
+	client.OnError = func(err error) { errs <- err }
+	client.Listen(pubsub.BitsTopic("1"))
+	runClient(t, client)
+	select {
+	case err := <-errs:
+		listenErr, ok := err.(*pubsub.ListenError)
+		if !ok || listenErr.Err != "ERR_BADAUTH" || !reflect.DeepEqual(listenErr.Topics, []string{pubsub.BitsTopic("1")}) {
+			t.Errorf("Expected a bad auth error.  Got %v.", err)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("No error reported.")
+	}
+	if topics := client.Topics(); len(topics) != 0 {
+		t.Errorf("Expected the refused topic to be dropped.  Got %v.", topics)
+	}
+}
+
+func TestClientResubscribes(t *testing.T) {
+	srv := twitchtest.NewPubSubServer()
+	defer srv.Close()
+	client := pubsub.NewClient("token")
+	client.Address = srv.URL()
+	client.ReconnectDelay = time.Millisecond
+	connects := make(chan bool, 3)
+	client.OnConnect = func() { connects <- true }
+	topic := pubsub.BitsTopic("1")
+	client.Listen(topic)
+	runClient(t, client)
+	waitForListen(t, srv, topic)
+
+	srv.Reconnect()
+	<-connects
+	<-connects
+	waitForListen(t, srv, topic)
+	srv.Disconnect()
+	select {
+	case <-connects:
+	case <-time.After(waitTimeout):
+		t.Fatal("The client did not reconnect.")
+	}
+	waitForListen(t, srv, topic)
+
+	client.Unlisten(topic)
+	client.Listen(pubsub.SubscribeTopic("1"))
+	waitForListen(t, srv, pubsub.SubscribeTopic("1"))
+	if n := srv.Publish(topic, "{}"); n != 0 {
+		t.Errorf("Expected no listener after UNLISTEN.  Got %d.", n)
+	}
+}
+
+func TestClientPing(t *testing.T) {
+	srv := twitchtest.NewPubSubServer()
+	defer srv.Close()
+	client := pubsub.NewClient("token")
+	client.Address = srv.URL()
+	client.PingInterval = 50 * time.Millisecond
+	client.PongTimeout = 500 * time.Millisecond
+	client.ReconnectDelay = time.Millisecond
+	connects := make(chan bool, 10)
+	client.OnConnect = func() { connects <- true }
+	runClient(t, client)
+	for i := 0; i < 2; i++ {
+		if err := srv.WaitForPing(waitTimeout); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(connects) != 1 {
+		t.Errorf("Expected 1 connect while the server answers.  Got %d.", len(connects))
+	}
+
+	// A server that stops answering is reconnected to.
+	srv.DropPings(true)
+	<-connects
+	select {
+	case <-connects:
+	case <-time.After(waitTimeout):
+		t.Fatal("The client did not reconnect.")
+	}
+}
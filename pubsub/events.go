@@ -0,0 +1,245 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Topic prefixes, the part of a topic before the first `.`.
+const (
+	bitsPrefix             = "channel-bits-events-v2"
+	subscribePrefix        = "channel-subscribe-events-v1"
+	channelPointsPrefix    = "channel-points-channel-v1"
+	whispersPrefix         = "whispers"
+	moderatorActionsPrefix = "chat_moderator_actions"
+)
+
+// BitsTopic returns the topic of the cheers in a channel.  Requires an oauth token of the channel with the
+// `bits:read` scope.
+func BitsTopic(channelID string) string {
+	return bitsPrefix + "." + channelID
+}
+
+// SubscribeTopic returns the topic of the subscriptions to a channel.  Requires an oauth token of the channel with the
+// `channel_subscriptions` scope.
+func SubscribeTopic(channelID string) string {
+	return subscribePrefix + "." + channelID
+}
+
+// ChannelPointsTopic returns the topic of the channel points redemptions in a channel.  Requires an oauth token of the
+// channel with the `channel:read:redemptions` scope.
+func ChannelPointsTopic(channelID string) string {
+	return channelPointsPrefix + "." + channelID
+}
+
+// WhispersTopic returns the topic of the whispers of a user.  Requires an oauth token of the user with the
+// `whispers:read` scope.
+func WhispersTopic(userID string) string {
+	return whispersPrefix + "." + userID
+}
+
+// ModeratorActionsTopic returns the topic of the moderator actions in a channel, as seen by one of its moderators.
+// Requires an oauth token of the moderator with the `channel:moderate` scope.
+func ModeratorActionsTopic(userID string, channelID string) string {
+	return moderatorActionsPrefix + "." + userID + "." + channelID
+}
+
+// topicPrefix returns the part of topic before the first `.`.
+func topicPrefix(topic string) string {
+	if i := strings.IndexByte(topic, '.'); i >= 0 {
+		return topic[:i]
+	}
+	return topic
+}
+
+// BadgeEntitlement is a new bits badge earned with a cheer.
+type BadgeEntitlement struct {
+	NewVersion      int `json:"new_version"`
+	PreviousVersion int `json:"previous_version"`
+}
+
+// BitsEvent is a cheer in a channel.  UserID and UserName are empty for anonymous cheers.
+type BitsEvent struct {
+	MessageID        string            `json:"-"`
+	IsAnonymous      bool              `json:"-"`
+	UserID           string            `json:"user_id"`
+	UserName         string            `json:"user_name"`
+	ChannelID        string            `json:"channel_id"`
+	ChannelName      string            `json:"channel_name"`
+	Time             time.Time         `json:"time"`
+	ChatMessage      string            `json:"chat_message"`
+	BitsUsed         int               `json:"bits_used"`
+	TotalBitsUsed    int               `json:"total_bits_used"`
+	Context          string            `json:"context"`
+	BadgeEntitlement *BadgeEntitlement `json:"badge_entitlement"`
+}
+
+type bitsMessage struct {
+	Data        BitsEvent `json:"data"`
+	MessageID   string    `json:"message_id"`
+	IsAnonymous bool      `json:"is_anonymous"`
+}
+
+func decodeBits(message []byte) (BitsEvent, error) {
+	m := bitsMessage{}
+	if err := json.Unmarshal(message, &m); err != nil {
+		return BitsEvent{}, err
+	}
+	m.Data.MessageID = m.MessageID
+	m.Data.IsAnonymous = m.IsAnonymous
+	return m.Data, nil
+}
+
+// Emote is an emote used in a message, by its position in the text.
+type Emote struct {
+	Start int         `json:"start"`
+	End   int         `json:"end"`
+	ID    json.Number `json:"id"`
+}
+
+// SubMessage is the message a user shares with a resubscription.
+type SubMessage struct {
+	Message string  `json:"message"`
+	Emotes  []Emote `json:"emotes"`
+}
+
+// The contexts of a SubscribeEvent.
+const (
+	SubContext           = "sub"
+	ResubContext         = "resub"
+	SubGiftContext       = "subgift"
+	AnonSubGiftContext   = "anonsubgift"
+	ResubGiftContext     = "resubgift"
+	AnonResubGiftContext = "anonresubgift"
+)
+
+// SubscribeEvent is a subscription to a channel.  For gifts, the user is the gifter and the recipient is the new
+// subscriber.
+type SubscribeEvent struct {
+	UserID               string     `json:"user_id"`
+	UserName             string     `json:"user_name"`
+	DisplayName          string     `json:"display_name"`
+	ChannelID            string     `json:"channel_id"`
+	ChannelName          string     `json:"channel_name"`
+	Time                 time.Time  `json:"time"`
+	SubPlan              string     `json:"sub_plan"`
+	SubPlanName          string     `json:"sub_plan_name"`
+	CumulativeMonths     int        `json:"cumulative_months"`
+	StreakMonths         int        `json:"streak_months"`
+	Context              string     `json:"context"`
+	IsGift               bool       `json:"is_gift"`
+	SubMessage           SubMessage `json:"sub_message"`
+	RecipientID          string     `json:"recipient_id"`
+	RecipientUserName    string     `json:"recipient_user_name"`
+	RecipientDisplayName string     `json:"recipient_display_name"`
+	MultiMonthDuration   int        `json:"multi_month_duration"`
+}
+
+// RedemptionUser is the user redeeming a reward.
+type RedemptionUser struct {
+	ID          string `json:"id"`
+	Login       string `json:"login"`
+	DisplayName string `json:"display_name"`
+}
+
+// Reward is a custom channel points reward.
+type Reward struct {
+	ID                                string `json:"id"`
+	ChannelID                         string `json:"channel_id"`
+	Title                             string `json:"title"`
+	Prompt                            string `json:"prompt"`
+	Cost                              int    `json:"cost"`
+	IsUserInputRequired               bool   `json:"is_user_input_required"`
+	IsSubOnly                         bool   `json:"is_sub_only"`
+	BackgroundColor                   string `json:"background_color"`
+	IsEnabled                         bool   `json:"is_enabled"`
+	IsPaused                          bool   `json:"is_paused"`
+	IsInStock                         bool   `json:"is_in_stock"`
+	ShouldRedemptionsSkipRequestQueue bool   `json:"should_redemptions_skip_request_queue"`
+}
+
+// Redemption is a channel points reward redeemed by a user.  Status is `UNFULFILLED` until the broadcaster handles
+// it, or `FULFILLED` for rewards that skip the request queue.
+type Redemption struct {
+	ID         string         `json:"id"`
+	User       RedemptionUser `json:"user"`
+	ChannelID  string         `json:"channel_id"`
+	RedeemedAt time.Time      `json:"redeemed_at"`
+	Reward     Reward         `json:"reward"`
+	UserInput  string         `json:"user_input"`
+	Status     string         `json:"status"`
+}
+
+type channelPointsMessage struct {
+	Type string `json:"type"`
+	Data struct {
+		Redemption Redemption `json:"redemption"`
+	} `json:"data"`
+}
+
+// Badge is a chat badge and its version.
+type Badge struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// WhisperTags describe the sender of a whisper.
+type WhisperTags struct {
+	Login       string  `json:"login"`
+	DisplayName string  `json:"display_name"`
+	Color       string  `json:"color"`
+	Badges      []Badge `json:"badges"`
+	Emotes      []Emote `json:"emotes"`
+}
+
+// WhisperRecipient is the receiver of a whisper.
+type WhisperRecipient struct {
+	ID          json.Number `json:"id"`
+	Username    string      `json:"username"`
+	DisplayName string      `json:"display_name"`
+	Color       string      `json:"color"`
+}
+
+// Whisper is a whisper received by a user.
+type Whisper struct {
+	ID        json.Number      `json:"id"`
+	MessageID string           `json:"message_id"`
+	ThreadID  string           `json:"thread_id"`
+	Body      string           `json:"body"`
+	SentTS    int64            `json:"sent_ts"`
+	FromID    json.Number      `json:"from_id"`
+	Tags      WhisperTags      `json:"tags"`
+	Recipient WhisperRecipient `json:"recipient"`
+}
+
+// Time returns the time the whisper was sent.
+func (w Whisper) Time() time.Time {
+	return time.Unix(w.SentTS, 0)
+}
+
+type whisperMessage struct {
+	Type       string  `json:"type"`
+	DataObject Whisper `json:"data_object"`
+}
+
+// ModeratorAction is an action taken by a moderator, like a ban or a change of chat settings.  Args depend on the
+// action, for `ban` they are the target login and the reason.
+type ModeratorAction struct {
+	// ChannelID is the channel the action was taken in, from the topic.
+	ChannelID        string   `json:"-"`
+	Type             string   `json:"type"`
+	ModerationAction string   `json:"moderation_action"`
+	Args             []string `json:"args"`
+	CreatedBy        string   `json:"created_by"`
+	CreatedByUserID  string   `json:"created_by_user_id"`
+	MsgID            string   `json:"msg_id"`
+	TargetUserID     string   `json:"target_user_id"`
+	TargetUserLogin  string   `json:"target_user_login"`
+	FromAutomod      bool     `json:"from_automod"`
+}
+
+type moderatorActionMessage struct {
+	Type string          `json:"type"`
+	Data ModeratorAction `json:"data"`
+}
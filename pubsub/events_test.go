@@ -0,0 +1,70 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTopics(t *testing.T) {
+	tests := map[string]string{
+		BitsTopic("44322889"):                        "channel-bits-events-v2.44322889",
+		SubscribeTopic("44322889"):                   "channel-subscribe-events-v1.44322889",
+		ChannelPointsTopic("44322889"):               "channel-points-channel-v1.44322889",
+		WhispersTopic("44322889"):                    "whispers.44322889",
+		ModeratorActionsTopic("123", "44322889"):     "chat_moderator_actions.123.44322889",
+		topicPrefix(ModeratorActionsTopic("1", "2")): moderatorActionsPrefix,
+	}
+	for got, expected := range tests {
+		if got != expected {
+			t.Errorf("Expected %q.  Got %q.", expected, got)
+		}
+	}
+}
+
+func TestDecodeBits(t *testing.T) {
+	message := `{"data":{"user_name":"dallasnchains","channel_name":"dallas","user_id":"129454141","channel_id":"44322889","time":"2017-02-09T13:23:58.168Z","chat_message":"cheer10000 New badge hype!","bits_used":10000,"total_bits_used":25000,"context":"cheer","badge_entitlement":{"new_version":25000,"previous_version":10000}},"version":"1.0","message_type":"bits_event","message_id":"8145728a4-35f0-4cf7-9dc0-f2ef24de1eb6","is_anonymous":true}`
+	e, err := decodeBits([]byte(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := BitsEvent{
+		MessageID:        "8145728a4-35f0-4cf7-9dc0-f2ef24de1eb6",
+		IsAnonymous:      true,
+		UserID:           "129454141",
+		UserName:         "dallasnchains",
+		ChannelID:        "44322889",
+		ChannelName:      "dallas",
+		Time:             time.Date(2017, 2, 9, 13, 23, 58, 168000000, time.UTC),
+		ChatMessage:      "cheer10000 New badge hype!",
+		BitsUsed:         10000,
+		TotalBitsUsed:    25000,
+		Context:          "cheer",
+		BadgeEntitlement: &BadgeEntitlement{NewVersion: 25000, PreviousVersion: 10000},
+	}
+	if !e.Time.Equal(expected.Time) {
+		t.Errorf("Expected %v.  Got %v.", expected.Time, e.Time)
+	}
+	e.Time = expected.Time
+	if e.BadgeEntitlement == nil || *e.BadgeEntitlement != *expected.BadgeEntitlement {
+		t.Errorf("Expected %#v.  Got %#v.", expected.BadgeEntitlement, e.BadgeEntitlement)
+	}
+	e.BadgeEntitlement = expected.BadgeEntitlement
+	if e != expected {
+		t.Errorf("Expected %#v.  Got %#v.", expected, e)
+	}
+}
+
+func TestDecodeSubscribe(t *testing.T) {
+	message := `{"user_name":"tww2","display_name":"TWW2","channel_name":"mr_woodchuck","user_id":"13405587","channel_id":"89614178","time":"2015-12-19T16:39:57-08:00","sub_plan":"1000","sub_plan_name":"Channel Subscription (mr_woodchuck)","cumulative_months":9,"streak_months":3,"context":"resub","is_gift":false,"sub_message":{"message":"A Twitch baby is born! KappaHD","emotes":[{"start":23,"end":7,"id":2867}]}}`
+	e := SubscribeEvent{}
+	if err := json.Unmarshal([]byte(message), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Context != ResubContext || e.CumulativeMonths != 9 || e.SubPlan != "1000" || e.IsGift {
+		t.Errorf("Unexpected subscription %#v.", e)
+	}
+	if len(e.SubMessage.Emotes) != 1 || e.SubMessage.Emotes[0].ID.String() != "2867" {
+		t.Errorf("Unexpected emotes %#v.", e.SubMessage.Emotes)
+	}
+}
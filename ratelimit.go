@@ -0,0 +1,130 @@
+package twitch2go
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimit is the number of requests per minute NewClient allows before the rate limiter starts blocking.
+const DefaultRateLimit = 800
+
+// RateLimiter is a token bucket shared by every request a Client makes to the API.  It is seeded with a fixed budget
+// and adapts to the Ratelimit-Limit, Ratelimit-Remaining and Ratelimit-Reset headers returned by Twitch.  It is safe
+// for concurrent use.
+type RateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	// rate is the refill rate in tokens per second.
+	rate float64
+	last time.Time
+	// blockedUntil is set when the server reports an exhausted budget; no tokens are handed out before it.
+	blockedUntil time.Time
+	now          func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing limit requests every per, starting with a full bucket.
+func NewRateLimiter(limit int, per time.Duration) *RateLimiter {
+	if limit < 1 {
+		limit = 1
+	}
+	if per <= 0 {
+		per = time.Minute
+	}
+	l := &RateLimiter{
+		capacity: float64(limit),
+		tokens:   float64(limit),
+		rate:     float64(limit) / per.Seconds(),
+		now:      time.Now,
+	}
+	l.last = l.now()
+	return l
+}
+
+// refill adds the tokens accumulated since the last call.  Must be called with l.mu held.
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens = math.Min(l.capacity, l.tokens+elapsed*l.rate)
+	}
+	l.last = now
+}
+
+// reserve takes a token if one is available, otherwise it returns how long to wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.refill(now)
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if !l.blockedUntil.IsZero() {
+		// The server side budget has been reset.
+		l.blockedUntil = time.Time{}
+		l.tokens = l.capacity
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Wait blocks until a request may be made or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		d := l.reserve()
+		if d == 0 {
+			return nil
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// Update adjusts the bucket from the rate limit headers of a response.  Headers that are missing or malformed are
+// ignored.
+func (l *RateLimiter) Update(h http.Header) {
+	limit, hasLimit := headerInt(h, "Ratelimit-Limit")
+	remaining, hasRemaining := headerInt(h, "Ratelimit-Remaining")
+	reset, hasReset := headerInt(h, "Ratelimit-Reset")
+	if !hasLimit && !hasRemaining {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.refill(now)
+	if hasLimit && limit > 0 && float64(limit) != l.capacity {
+		// Twitch budgets are per minute.
+		l.capacity = float64(limit)
+		l.rate = float64(limit) / time.Minute.Seconds()
+		l.tokens = math.Min(l.tokens, l.capacity)
+	}
+	if hasRemaining {
+		l.tokens = math.Min(l.tokens, float64(remaining))
+		if remaining <= 0 && hasReset {
+			resetAt := time.Unix(int64(reset), 0)
+			if resetAt.After(l.blockedUntil) {
+				l.blockedUntil = resetAt
+			}
+		}
+	}
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v := h.Get(key)
+	if v == "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
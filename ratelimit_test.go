@@ -0,0 +1,104 @@
+package twitch2go
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for the rate limiter.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestRateLimiter(limit int, per time.Duration) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1500000000, 0)}
+	l := NewRateLimiter(limit, per)
+	l.now = clock.Now
+	l.last = clock.Now()
+	return l, clock
+}
+
+func TestRateLimiterBucket(t *testing.T) {
+	l, clock := newTestRateLimiter(2, time.Second)
+	for i := 0; i < 2; i++ {
+		if d := l.reserve(); d != 0 {
+			t.Fatalf("reserve %d: Expected a token.  Got wait of %s.", i, d)
+		}
+	}
+	if d := l.reserve(); d != 500*time.Millisecond {
+		t.Errorf("Expected to wait 500ms for the next token.  Got %s.", d)
+	}
+	clock.Advance(500 * time.Millisecond)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("Expected a refilled token.  Got wait of %s.", d)
+	}
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	l, clock := newTestRateLimiter(800, time.Minute)
+	reset := clock.Now().Add(10 * time.Second)
+	l.Update(http.Header{
+		"Ratelimit-Limit":     []string{"120"},
+		"Ratelimit-Remaining": []string{"0"},
+		"Ratelimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+	})
+	if l.capacity != 120 {
+		t.Errorf("Expected capacity 120.  Got %v.", l.capacity)
+	}
+	if d := l.reserve(); d != 10*time.Second {
+		t.Errorf("Expected to wait until the reset.  Got %s.", d)
+	}
+	clock.Advance(10 * time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("Expected a token after the reset.  Got wait of %s.", d)
+	}
+	if l.tokens != 119 {
+		t.Errorf("Expected a full bucket after the reset.  Got %v tokens.", l.tokens)
+	}
+}
+
+func TestRateLimiterWaitHonorsContext(t *testing.T) {
+	l := NewRateLimiter(1, time.Hour)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected %v.  Got %v.", context.DeadlineExceeded, err)
+	}
+}
+
+func TestClientFeedsRateLimiter(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{}`, status: http.StatusOK, header: map[string]string{
+		"Ratelimit-Limit":     "30",
+		"Ratelimit-Remaining": "5",
+	}}
+	client := newTestClient(fakeRT)
+	if _, err := client.GetChannelByID("6391593"); err != nil {
+		t.Fatal(err)
+	}
+	if client.RateLimiter.capacity != 30 {
+		t.Errorf("Expected capacity 30.  Got %v.", client.RateLimiter.capacity)
+	}
+	if client.RateLimiter.tokens > 5 {
+		t.Errorf("Expected at most 5 tokens.  Got %v.", client.RateLimiter.tokens)
+	}
+}
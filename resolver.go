@@ -0,0 +1,187 @@
+package twitch2go
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultResolverSize is the number of users a Resolver caches when created with a size of 0.
+	DefaultResolverSize = 10000
+	// DefaultResolverTTL is how long a Resolver caches a user when created with a TTL of 0.
+	DefaultResolverTTL = time.Hour
+)
+
+// Resolver maps login names to user IDs and back, caching the results.  Every method taking a channelID or userID can
+// be called with a login by resolving it first:
+//
+//	resolver := twitch2go.NewResolver(client, 0, 0)
+//	channelID, err := resolver.ID(ctx, "chosenken")
+//	if err != nil {
+//		...
+//	}
+//	channel, err := client.GetChannelByIDContext(ctx, channelID)
+//
+// The cache holds up to size users, evicting the least recently used, and forgets users after ttl so renamed accounts
+// are picked up.  A Resolver is safe for concurrent use.
+type Resolver struct {
+	client  *Client
+	size    int
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries *list.List
+	byLogin map[string]*list.Element
+	byID    map[string]*list.Element
+	now     func() time.Time
+}
+
+// resolverEntry is a cached login and ID pair.
+type resolverEntry struct {
+	login   string
+	id      string
+	expires time.Time
+}
+
+// NewResolver returns a Resolver that looks up users with client.  A size or ttl of 0 uses DefaultResolverSize or
+// DefaultResolverTTL.
+func NewResolver(client *Client, size int, ttl time.Duration) *Resolver {
+	if size <= 0 {
+		size = DefaultResolverSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultResolverTTL
+	}
+	return &Resolver{
+		client:  client,
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		byLogin: map[string]*list.Element{},
+		byID:    map[string]*list.Element{},
+		now:     time.Now,
+	}
+}
+
+// ID returns the ID of the user with the given login.  If there is no such user the error satisfies IsNotFound.
+func (r *Resolver) ID(ctx context.Context, login string) (string, error) {
+	ids, err := r.IDs(ctx, []string{login})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id, ok := ids[strings.ToLower(login)]
+	if !ok {
+		return "", errors.Trace(&Error{Status: http.StatusNotFound, ErrorText: "Not Found", Message: fmt.Sprintf("User '%s' does not exist", login)})
+	}
+	return id, nil
+}
+
+// IDs returns the IDs of the users with the given logins, keyed by lowercased login.  Logins that do not exist are left
+// out.  Logins missing from the cache are looked up in batches of 100.
+func (r *Resolver) IDs(ctx context.Context, logins []string) (map[string]string, error) {
+	ids := make(map[string]string, len(logins))
+	seen := make(map[string]bool, len(logins))
+	var missing []string
+	r.mu.Lock()
+	for _, login := range logins {
+		login = strings.ToLower(login)
+		if seen[login] {
+			continue
+		}
+		seen[login] = true
+		if e := r.lookup(r.byLogin, login); e != nil {
+			ids[login] = e.id
+		} else {
+			missing = append(missing, login)
+		}
+	}
+	r.mu.Unlock()
+	if len(missing) == 0 {
+		return ids, nil
+	}
+	users, err := r.client.GetUsersByLoginContext(ctx, missing)
+	if err != nil {
+		return nil, errors.Annotate(err, "Resolver")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range users {
+		login := strings.ToLower(u.Name)
+		ids[login] = u.ID.String()
+		r.add(login, u.ID.String())
+	}
+	return ids, nil
+}
+
+// Login returns the login of the user with the given ID.  If there is no such user the error satisfies IsNotFound.
+func (r *Resolver) Login(ctx context.Context, id string) (string, error) {
+	r.mu.Lock()
+	e := r.lookup(r.byID, id)
+	r.mu.Unlock()
+	if e != nil {
+		return e.login, nil
+	}
+	user, err := r.client.GetUserByIDContext(ctx, id)
+	if err != nil {
+		return "", errors.Annotate(err, "Resolver")
+	}
+	login := strings.ToLower(user.Name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.add(login, id)
+	return login, nil
+}
+
+// Add caches the given login and ID pair, for example from a user fetched by other means.
+func (r *Resolver) Add(login string, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.add(strings.ToLower(login), id)
+}
+
+// lookup returns the live entry for key in index, marking it as recently used.  Expired entries are removed.  Must be
+// called with r.mu held.
+func (r *Resolver) lookup(index map[string]*list.Element, key string) *resolverEntry {
+	el, ok := index[key]
+	if !ok {
+		return nil
+	}
+	e := el.Value.(*resolverEntry)
+	if !r.now().Before(e.expires) {
+		r.remove(el)
+		return nil
+	}
+	r.entries.MoveToFront(el)
+	return e
+}
+
+// add caches a login and ID pair, replacing entries for either and evicting the least recently used entry when the
+// cache is full.  Must be called with r.mu held.
+func (r *Resolver) add(login string, id string) {
+	// A login can move to another account and an account can be renamed, so drop whatever either side mapped to.
+	if el, ok := r.byLogin[login]; ok {
+		r.remove(el)
+	}
+	if el, ok := r.byID[id]; ok {
+		r.remove(el)
+	}
+	el := r.entries.PushFront(&resolverEntry{login: login, id: id, expires: r.now().Add(r.ttl)})
+	r.byLogin[login] = el
+	r.byID[id] = el
+	for r.entries.Len() > r.size {
+		r.remove(r.entries.Back())
+	}
+}
+
+// remove drops an entry from the cache.  Must be called with r.mu held.
+func (r *Resolver) remove(el *list.Element) {
+	e := r.entries.Remove(el).(*resolverEntry)
+	delete(r.byLogin, e.login)
+	delete(r.byID, e.id)
+}
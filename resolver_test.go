@@ -0,0 +1,129 @@
+package twitch2go
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// usersRoundTripper answers users by login requests with a user for every requested login, with the login's length
+// as ID, and counts the requests.
+type usersRoundTripper struct {
+	requests []*http.Request
+}
+
+func (rt *usersRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, r)
+	var users []string
+	if login := r.URL.Query().Get("login"); login != "" {
+		for _, l := range strings.Split(login, ",") {
+			if l != "nobody" {
+				users = append(users, `{"_id": "`+userIDFor(l)+`", "name": "`+l+`"}`)
+			}
+		}
+	} else {
+		// A user by ID request, the ID is the last path element.
+		parts := strings.Split(r.URL.Path, "/")
+		users = append(users, `{"_id": "`+parts[len(parts)-1]+`", "name": "user`+parts[len(parts)-1]+`"}`)
+		return (&FakeRoundTripper{message: users[0], status: http.StatusOK}).RoundTrip(r)
+	}
+	body := `{"_total": 0, "users": [` + strings.Join(users, ",") + `]}`
+	return (&FakeRoundTripper{message: body, status: http.StatusOK}).RoundTrip(r)
+}
+
+func userIDFor(login string) string {
+	return "1" + strings.Repeat("0", len(login))
+}
+
+func TestGetUsersByLoginBatches(t *testing.T) {
+	var logins []string
+	for i := 0; i < 150; i++ {
+		logins = append(logins, "user"+strings.Repeat("x", i))
+	}
+	rt := &usersRoundTripper{}
+	client := newTestClient(rt)
+	users, err := client.GetUsersByLogin(logins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 150 {
+		t.Errorf("Expected 150 users.  Got %d.", len(users))
+	}
+	if len(rt.requests) != 2 {
+		t.Errorf("Expected 2 requests.  Got %d.", len(rt.requests))
+	}
+	if n := len(strings.Split(rt.requests[0].URL.Query().Get("login"), ",")); n != 100 {
+		t.Errorf("Expected 100 logins in the first request.  Got %d.", n)
+	}
+}
+
+func TestResolverCaches(t *testing.T) {
+	rt := &usersRoundTripper{}
+	resolver := NewResolver(newTestClient(rt), 0, 0)
+	ctx := context.Background()
+	id, err := resolver.ID(ctx, "ChosenKen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != userIDFor("chosenken") {
+		t.Errorf("Expected %#v.  Got %#v.", userIDFor("chosenken"), id)
+	}
+	login, err := resolver.Login(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if login != "chosenken" {
+		t.Errorf("Expected %#v.  Got %#v.", "chosenken", login)
+	}
+	ids, err := resolver.IDs(ctx, []string{"chosenken", "cohhcarnage", "cohhcarnage", "nobody"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids["cohhcarnage"] != userIDFor("cohhcarnage") {
+		t.Errorf("Unexpected IDs %#v", ids)
+	}
+	if len(rt.requests) != 2 {
+		t.Errorf("Expected 2 requests.  Got %d.", len(rt.requests))
+	}
+	if logins := rt.requests[1].URL.Query().Get("login"); logins != "cohhcarnage,nobody" {
+		t.Errorf("Expected only uncached logins to be requested.  Got %#v.", logins)
+	}
+	if _, err := resolver.ID(ctx, "nobody"); !IsNotFound(err) {
+		t.Errorf("Expected a not found error.  Got %v.", err)
+	}
+}
+
+func TestResolverExpiresAndEvicts(t *testing.T) {
+	rt := &usersRoundTripper{}
+	resolver := NewResolver(newTestClient(rt), 2, time.Minute)
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+	ctx := context.Background()
+	resolver.Add("a", "1")
+	resolver.Add("b", "2")
+	if login, err := resolver.Login(ctx, "1"); err != nil || login != "a" {
+		t.Fatalf("Expected the added login.  Got %#v, %v.", login, err)
+	}
+	// a was used last, so c evicts b.
+	resolver.Add("c", "3")
+	if _, err := resolver.Login(ctx, "2"); err != nil {
+		t.Fatal(err)
+	}
+	if len(rt.requests) != 1 || !strings.HasSuffix(rt.requests[0].URL.Path, "/users/2") {
+		t.Fatalf("Expected b to be evicted and fetched again.  Got %d requests.", len(rt.requests))
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := resolver.ID(ctx, "c"); err != nil {
+		t.Fatal(err)
+	}
+	if len(rt.requests) != 2 {
+		t.Errorf("Expected the expired entry to be fetched again.  Got %d requests.", len(rt.requests))
+	}
+	// A renamed account replaces its old login.
+	resolver.Add("renamed", userIDFor("c"))
+	if _, ok := resolver.byLogin["c"]; ok {
+		t.Error("Expected the old login to be dropped.")
+	}
+}
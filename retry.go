@@ -0,0 +1,170 @@
+package twitch2go
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how the Client retries requests that fail with a transport error or a retryable status code.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including the first one.  Values below 1 are treated as 1.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry.  It doubles after every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts, including delays asked for by a Retry-After header.  Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0 to 1) by which each delay is randomly shortened, so that concurrent callers do not retry
+	// in lockstep.
+	Jitter float64
+	// Methods lists the HTTP methods that may be retried.  When empty only GET and HEAD requests are retried.
+	Methods []string
+	// RetryableStatus lists the HTTP status codes that are retried.
+	RetryableStatus []int
+	// RetryableError reports whether a transport error should be retried.  When nil every error is retried.
+	// Context cancellation and deadline errors are never retried.
+	RetryableError func(err error) bool
+	// RespectRetryAfter makes the Client wait for the duration given in a Retry-After response header, when present.
+	RespectRetryAfter bool
+}
+
+// DefaultRetryPolicy is the policy used by NewClient.  It retries idempotent GET and HEAD requests up to three times
+// on transport errors, 429 and 5xx responses.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.2,
+	RetryableStatus: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+	RespectRetryAfter: true,
+}
+
+// NoRetry disables retries on a Client.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	if len(p.Methods) == 0 {
+		return method == http.MethodGet || method == http.MethodHead
+	}
+	for _, m := range p.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryError reports whether a request that failed with err on the given attempt should be tried again.
+func (p *RetryPolicy) retryError(method string, attempt int, err error) bool {
+	if attempt >= p.maxAttempts() || !p.allowsMethod(method) {
+		return false
+	}
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return false
+	}
+	if p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+	return true
+}
+
+// retryStatus reports whether a request that got the given status code on the given attempt should be tried again.
+func (p *RetryPolicy) retryStatus(method string, attempt int, status int) bool {
+	if attempt >= p.maxAttempts() || !p.allowsMethod(method) {
+		return false
+	}
+	for _, s := range p.RetryableStatus {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the attempt following the given one.  resp may be nil.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if p.RespectRetryAfter && resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return p.capDelay(d)
+		}
+	}
+	d := p.BaseDelay
+	for i := 1; i < attempt && d > 0; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	return jitter(p.capDelay(d), p.Jitter)
+}
+
+// jitter shortens d by a random amount of up to fraction of d, so that clients started together spread out.  fraction
+// is clamped to 0 to 1.  d is never lengthened, so it stays an upper bound like RetryPolicy.MaxDelay.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	return d - time.Duration(rand.Float64()*fraction*float64(d))
+}
+
+func (p *RetryPolicy) capDelay(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done, whichever happens first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package twitch2go
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// scriptedResponse is a single step of a scriptedRoundTripper.  If err is set it is returned instead of a response.
+type scriptedResponse struct {
+	status int
+	body   string
+	header map[string]string
+	err    error
+}
+
+// scriptedRoundTripper returns the scripted responses in order, repeating the last one once the script runs out.
+type scriptedRoundTripper struct {
+	script []scriptedResponse
+	calls  int
+}
+
+func (rt *scriptedRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	i := rt.calls
+	if i >= len(rt.script) {
+		i = len(rt.script) - 1
+	}
+	rt.calls++
+	step := rt.script[i]
+	if step.err != nil {
+		return nil, step.err
+	}
+	resp := &http.Response{
+		StatusCode: step.status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(step.body)),
+		Request:    r,
+	}
+	for k, v := range step.header {
+		resp.Header.Set(k, v)
+	}
+	return resp, nil
+}
+
+func newRetryTestClient(rt http.RoundTripper, attempts int) *Client {
+	client := newTestClient(rt)
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts:       attempts,
+		BaseDelay:         time.Millisecond,
+		MaxDelay:          10 * time.Millisecond,
+		RetryableStatus:   DefaultRetryPolicy.RetryableStatus,
+		RespectRetryAfter: true,
+	}
+	return client
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{status: http.StatusBadGateway, body: "bad gateway"},
+		{status: http.StatusServiceUnavailable, body: "unavailable"},
+		{status: http.StatusOK, body: `{"name": "chosenken"}`},
+	}}
+	client := newRetryTestClient(rt, 3)
+	channel, err := client.GetChannelByID("6391593")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel.Name != "chosenken" {
+		t.Errorf("Expected channel chosenken.  Got %q.", channel.Name)
+	}
+	if rt.calls != 3 {
+		t.Errorf("Expected 3 attempts.  Got %d.", rt.calls)
+	}
+}
+
+func TestRetryOnTransportError(t *testing.T) {
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{err: errors.New("connection reset by peer")},
+		{status: http.StatusOK, body: `{"name": "chosenken"}`},
+	}}
+	client := newRetryTestClient(rt, 3)
+	if _, err := client.GetUserByID("6391593"); err != nil {
+		t.Fatal(err)
+	}
+	if rt.calls != 2 {
+		t.Errorf("Expected 2 attempts.  Got %d.", rt.calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{status: http.StatusInternalServerError, body: "boom"},
+	}}
+	client := newRetryTestClient(rt, 3)
+	_, err := client.GetChannelByID("6391593")
+	if err == nil {
+		t.Fatal("Expected an error after exhausting retries.")
+	}
+	if rt.calls != 3 {
+		t.Errorf("Expected 3 attempts.  Got %d.", rt.calls)
+	}
+}
+
+func TestRetrySkipsNonRetryableStatus(t *testing.T) {
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{status: http.StatusNotFound, body: "not found"},
+		{status: http.StatusOK, body: `{}`},
+	}}
+	client := newRetryTestClient(rt, 3)
+	if _, err := client.GetChannelByID("6391593"); err == nil {
+		t.Fatal("Expected a not found error.")
+	}
+	if rt.calls != 1 {
+		t.Errorf("Expected 1 attempt.  Got %d.", rt.calls)
+	}
+}
+
+func TestRetryOnlyIdempotentMethods(t *testing.T) {
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{status: http.StatusServiceUnavailable, body: "unavailable"},
+		{status: http.StatusOK, body: `{}`},
+	}}
+	client := newRetryTestClient(rt, 3)
+	if _, err := client.do("POST", "/channels/1/commercial", &doOptions{}); err == nil {
+		t.Fatal("Expected POST not to be retried.")
+	}
+	if rt.calls != 1 {
+		t.Errorf("Expected 1 attempt.  Got %d.", rt.calls)
+	}
+}
+
+func TestRetryAppliesToChatters(t *testing.T) {
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{status: http.StatusGatewayTimeout, body: "timeout"},
+		{status: http.StatusOK, body: `{"chatter_count": 1}`},
+	}}
+	client := newRetryTestClient(rt, 2)
+	chatters, err := client.GetChatters("chosenken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chatters.ChatterCount != 1 {
+		t.Errorf("Expected 1 chatter.  Got %d.", chatters.ChatterCount)
+	}
+}
+
+func TestRetryHonorsContext(t *testing.T) {
+	rt := &scriptedRoundTripper{script: []scriptedResponse{
+		{status: http.StatusTooManyRequests, body: "slow down", header: map[string]string{"Retry-After": "60"}},
+	}}
+	client := newRetryTestClient(rt, 3)
+	client.RetryPolicy.MaxDelay = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetChannelByIDContext(ctx, "6391593"); err == nil {
+		t.Fatal("Expected the context deadline to abort the retry.")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected retry to stop at the context deadline.  Took %s.", elapsed)
+	}
+	if rt.calls != 1 {
+		t.Errorf("Expected 1 attempt.  Got %d.", rt.calls)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, RespectRetryAfter: true}
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 5: time.Second} {
+		if d := p.delay(attempt, nil); d != expected {
+			t.Errorf("delay(%d): Expected %s.  Got %s.", attempt, expected, d)
+		}
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if d := p.delay(1, resp); d != time.Second {
+		t.Errorf("delay with Retry-After: Expected the capped %s.  Got %s.", time.Second, d)
+	}
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.delay(2, nil); d < 100*time.Millisecond || d > 200*time.Millisecond {
+			t.Fatalf("delay with jitter: Expected between 100ms and 200ms.  Got %s.", d)
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package twitch2go
 
 import (
+	"context"
 	"encoding/json"
+	"strconv"
+
+	"github.com/juju/errors"
 )
 
 type Result struct {
@@ -13,10 +17,16 @@ var searchURL = "search/channels"
 
 // SearchChannels Searches for the given channel and returns the results
 func (c *Client) SearchChannels(channel string) (*[]Channel, error) {
+	return c.SearchChannelsContext(context.Background(), channel)
+}
+
+// SearchChannelsContext is like SearchChannels but uses the given context for the request.
+func (c *Client) SearchChannelsContext(ctx context.Context, channel string) (*[]Channel, error) {
 	doOptions := &doOptions{
 		params: map[string]string{
 			"query": channel,
 		},
+		context: ctx,
 	}
 
 	resp, err := c.do("GET", searchURL, doOptions)
@@ -37,7 +47,12 @@ func (c *Client) SearchChannels(channel string) (*[]Channel, error) {
 // SearchExactChannel Searches for the given channel and returns
 // the channel if the channel name is an exact match
 func (c *Client) SearchExactChannel(channel string) (*Channel, error) {
-	channels, err := c.SearchChannels(channel)
+	return c.SearchExactChannelContext(context.Background(), channel)
+}
+
+// SearchExactChannelContext is like SearchExactChannel but uses the given context for the request.
+func (c *Client) SearchExactChannelContext(ctx context.Context, channel string) (*Channel, error) {
+	channels, err := c.SearchChannelsContext(ctx, channel)
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +67,16 @@ func (c *Client) SearchExactChannel(channel string) (*Channel, error) {
 var searchUserUrl = "search/users"
 
 func (c *Client) SearchUsers(user string) (*[]User, error) {
+	return c.SearchUsersContext(context.Background(), user)
+}
+
+// SearchUsersContext is like SearchUsers but uses the given context for the request.
+func (c *Client) SearchUsersContext(ctx context.Context, user string) (*[]User, error) {
 	doOptions := &doOptions{
 		params: map[string]string{
 			"query": user,
 		},
+		context: ctx,
 	}
 
 	resp, err := c.do("GET", searchUserUrl, doOptions)
@@ -72,7 +93,12 @@ func (c *Client) SearchUsers(user string) (*[]User, error) {
 }
 
 func (c *Client) SearchExactUser(user string) (*User, error) {
-	users, err := c.SearchUsers(user)
+	return c.SearchExactUserContext(context.Background(), user)
+}
+
+// SearchExactUserContext is like SearchExactUser but uses the given context for the request.
+func (c *Client) SearchExactUserContext(ctx context.Context, user string) (*User, error) {
+	users, err := c.SearchUsersContext(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -83,3 +109,54 @@ func (c *Client) SearchExactUser(user string) (*User, error) {
 	}
 	return nil, nil
 }
+
+var searchStreamsURL = "search/streams"
+
+/*
+SearchStreams searches for live streams whose game, channel name or status matches the given query.
+
+The function takes in four parameters:
+
+	query:
+		The search query.
+
+	limit:
+		The number of streams to return.  Max is 100, default is 25.
+
+	offset:
+		The offset in the list to return.
+
+	hls:
+		If true only HLS streams are returned, if false only RTMP streams.  nil returns both, use Bool to set it.
+*/
+func (c *Client) SearchStreams(query string, limit int, offset int, hls *bool) (*Streams, error) {
+	return c.SearchStreamsContext(context.Background(), query, limit, offset, hls)
+}
+
+// SearchStreamsContext is like SearchStreams but uses the given context for the request.
+func (c *Client) SearchStreamsContext(ctx context.Context, query string, limit int, offset int, hls *bool) (*Streams, error) {
+	limit = pageLimit(limit, 25)
+	doOptions := &doOptions{
+		params: map[string]string{
+			"query":  query,
+			"limit":  strconv.Itoa(limit),
+			"offset": strconv.Itoa(offset),
+		},
+		context: ctx,
+	}
+	if hls != nil {
+		doOptions.params["hls"] = strconv.FormatBool(*hls)
+	}
+
+	resp, err := c.do("GET", searchStreamsURL, doOptions)
+	if err != nil {
+		return nil, errors.Annotate(err, "SearchStreams")
+	}
+	defer resp.Body.Close()
+	streams := &Streams{}
+	err = json.NewDecoder(resp.Body).Decode(streams)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return streams, nil
+}
@@ -1,16 +1,31 @@
 package twitch2go
 
 import (
+	"context"
 	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/juju/errors"
 )
 
-// GetStreamByChannel returns a StreamResponse for the given channel
+// GetStreamByChannel returns the live stream of the given channel.  If the channel is offline it returns a nil stream
+// and ErrStreamOffline, which IsStreamOffline detects:
+//
+//	stream, err := client.GetStreamByChannel(channelID)
+//	if twitch2go.IsStreamOffline(err) {
+//		...
+//	}
 func (c *Client) GetStreamByChannel(channelID string) (*Stream, error) {
+	return c.GetStreamByChannelContext(context.Background(), channelID)
+}
+
+// GetStreamByChannelContext is like GetStreamByChannel but uses the given context for the request.
+func (c *Client) GetStreamByChannelContext(ctx context.Context, channelID string) (*Stream, error) {
 	url := "/streams/" + channelID
 	// Do the request
-	resp, err := c.do("GET", url, &doOptions{})
+	resp, err := c.do("GET", url, &doOptions{context: ctx})
 	if err != nil {
 		return nil, errors.Annotate(err, "GetStreamByChannel")
 	}
@@ -20,14 +35,24 @@ func (c *Client) GetStreamByChannel(channelID string) (*Stream, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "Error decoding JSON")
 	}
-	return &stream.Stream, nil
+	if stream.Stream == nil {
+		return nil, errors.Trace(ErrStreamOffline)
+	}
+	return stream.Stream, nil
 }
 
 // GetFollowedStreams returns a list of streams the user follows, based on user auth token.
 func (c *Client) GetFollowedStreams(oauth string) (*FollowedStream, error) {
+	return c.GetFollowedStreamsContext(context.Background(), oauth)
+}
+
+// GetFollowedStreamsContext is like GetFollowedStreams but uses the given context for the request.
+func (c *Client) GetFollowedStreamsContext(ctx context.Context, oauth string) (*FollowedStream, error) {
 	url := "/streams/followed"
 	opts := &doOptions{
-		oauth: oauth,
+		oauth:   oauth,
+		scope:   "user_read",
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, opts)
@@ -42,3 +67,173 @@ func (c *Client) GetFollowedStreams(oauth string) (*FollowedStream, error) {
 	}
 	return fs, nil
 }
+
+/*
+GetFeaturedStreams returns the streams featured on the Twitch front page.
+
+The function takes in two parameters:
+
+	limit:
+		The number of featured streams to return.  Max is 100, default is 25.
+
+	offset:
+		The offset in the list to return.
+*/
+func (c *Client) GetFeaturedStreams(limit int, offset int) (*FeaturedStreams, error) {
+	return c.GetFeaturedStreamsContext(context.Background(), limit, offset)
+}
+
+// GetFeaturedStreamsContext is like GetFeaturedStreams but uses the given context for the request.
+func (c *Client) GetFeaturedStreamsContext(ctx context.Context, limit int, offset int) (*FeaturedStreams, error) {
+	limit = pageLimit(limit, 25)
+	opts := &doOptions{
+		params: map[string]string{
+			"limit":  strconv.Itoa(limit),
+			"offset": strconv.Itoa(offset),
+		},
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("GET", "/streams/featured", opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetFeaturedStreams")
+	}
+	defer resp.Body.Close()
+	featured := &FeaturedStreams{}
+	err = json.NewDecoder(resp.Body).Decode(featured)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return featured, nil
+}
+
+// GetStreamsSummary returns the number of live channels and their viewers.  If game is not empty only streams of that
+// game are counted.
+func (c *Client) GetStreamsSummary(game string) (*StreamsSummary, error) {
+	return c.GetStreamsSummaryContext(context.Background(), game)
+}
+
+// GetStreamsSummaryContext is like GetStreamsSummary but uses the given context for the request.
+func (c *Client) GetStreamsSummaryContext(ctx context.Context, game string) (*StreamsSummary, error) {
+	opts := &doOptions{context: ctx}
+	if game != "" {
+		opts.params = map[string]string{"game": game}
+	}
+	// Do the request
+	resp, err := c.do("GET", "/streams/summary", opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetStreamsSummary")
+	}
+	defer resp.Body.Close()
+	summary := &StreamsSummary{}
+	err = json.NewDecoder(resp.Body).Decode(summary)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return summary, nil
+}
+
+// maxStreamRequests is the number of chunks GetStreams requests concurrently.
+const maxStreamRequests = 4
+
+// StreamsOptions filters the streams returned by GetStreams.  Empty fields are not sent.
+type StreamsOptions struct {
+	// Game is the name of a game, like `Overwatch`.
+	Game string
+	// Language is a broadcaster language code, like `en`.
+	Language string
+	// StreamType is one of AllStreams, LiveStreams and Playlists.  Default is LiveStreams.
+	StreamType StreamType
+}
+
+/*
+GetStreams returns the live streams of the given channels, keyed by channel ID.  Offline channels and streams not
+matching opts are left out of the map.
+
+Any number of channel IDs can be given.  They are requested 100 at a time, with up to four requests in flight, and the
+first failing request fails the whole call.
+*/
+func (c *Client) GetStreams(channelIDs []string, opts StreamsOptions) (map[string]Stream, error) {
+	return c.GetStreamsContext(context.Background(), channelIDs, opts)
+}
+
+// GetStreamsContext is like GetStreams but uses the given context for the requests.
+func (c *Client) GetStreamsContext(ctx context.Context, channelIDs []string, opts StreamsOptions) (map[string]Stream, error) {
+	var chunks [][]string
+	seen := map[string]bool{}
+	for _, id := range channelIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if len(chunks) == 0 || len(chunks[len(chunks)-1]) == maxPageSize {
+			chunks = append(chunks, make([]string, 0, maxPageSize))
+		}
+		chunks[len(chunks)-1] = append(chunks[len(chunks)-1], id)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		limit    = make(chan struct{}, maxStreamRequests)
+	)
+	result := make(map[string]Stream, len(seen))
+	for _, chunk := range chunks {
+		wg.Add(1)
+		go func(chunk []string) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			streams, err := c.getStreams(ctx, chunk, opts)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					// No point finishing the other chunks.
+					cancel()
+				}
+				return
+			}
+			for _, s := range streams {
+				result[s.Channel.ID.String()] = s
+			}
+		}(chunk)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// getStreams returns the streams of at most 100 channels matching opts.
+func (c *Client) getStreams(ctx context.Context, channelIDs []string, opts StreamsOptions) ([]Stream, error) {
+	params := map[string]string{
+		"channel": strings.Join(channelIDs, ","),
+		"limit":   strconv.Itoa(maxPageSize),
+	}
+	if opts.Game != "" {
+		params["game"] = opts.Game
+	}
+	if opts.Language != "" {
+		params["language"] = opts.Language
+	}
+	if opts.StreamType != "" {
+		params["stream_type"] = string(opts.StreamType)
+	}
+	// Do the request
+	resp, err := c.do("GET", "/streams", &doOptions{params: params, context: ctx})
+	if err != nil {
+		return nil, errors.Annotate(err, "GetStreams")
+	}
+	defer resp.Body.Close()
+	streams := &Streams{}
+	err = json.NewDecoder(resp.Body).Decode(streams)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return streams.Streams, nil
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -59,7 +62,7 @@ func TestGetStreamByChannel(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !reflect.DeepEqual(*stream, expected.Stream) {
+	if !reflect.DeepEqual(stream, expected.Stream) {
 		t.Errorf("GetStreamByChannel(%q): Expected %#v.  Got %#v.", channelID, expected, stream)
 	}
 
@@ -70,19 +73,17 @@ func TestGetOfflineStream(t *testing.T) {
 	jsonResponse := `{
   "stream": null
 }`
-	var expected StreamResponse
-	err := json.Unmarshal([]byte(jsonResponse), &expected)
-	if err != nil {
-		t.Fatal(err)
-	}
 	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
 	client := newTestClient(fakeRT)
 	stream, err := client.GetStreamByChannel(channelID)
-	if err != nil {
-		t.Fatal(err)
+	if !IsStreamOffline(err) {
+		t.Fatalf("GetStreamByChannel(%q): Expected an offline error.  Got %v.", channelID, err)
 	}
-	if !reflect.DeepEqual(*stream, expected.Stream) {
-		t.Errorf("GetStreamByChannel(%q): Expected %#v.  Got %#v.", channelID, expected, stream)
+	if stream != nil {
+		t.Errorf("GetStreamByChannel(%q): Expected a nil stream.  Got %#v.", channelID, stream)
+	}
+	if IsStreamOffline(nil) || IsStreamOffline(&Error{Status: http.StatusNotFound}) {
+		t.Error("Expected other errors not to be reported as offline.")
 	}
 }
 
@@ -145,3 +146,185 @@ func TestGetFollowedStream(t *testing.T) {
 		t.Errorf("GetFollowedStream(%q): Expected %#v.  Got %#v.", oauth, expected, stream)
 	}
 }
+
+// channelStreamsRoundTripper answers stream list requests with a live stream for every requested channel whose ID is
+// even, failing requests that include failID.
+type channelStreamsRoundTripper struct {
+	mu       sync.Mutex
+	failID   string
+	requests []*http.Request
+}
+
+func (rt *channelStreamsRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, r)
+	rt.mu.Unlock()
+	var streams []string
+	for _, id := range strings.Split(r.URL.Query().Get("channel"), ",") {
+		if id == rt.failID {
+			return (&FakeRoundTripper{message: `{"error":"Bad Request","status":400,"message":"bad channel"}`, status: http.StatusBadRequest}).RoundTrip(r)
+		}
+		if n, _ := strconv.Atoi(id); n%2 == 0 {
+			streams = append(streams, `{"_id": 1`+id+`, "game": "Overwatch", "channel": {"_id": `+id+`}}`)
+		}
+	}
+	body := `{"_total": ` + strconv.Itoa(len(streams)) + `, "streams": [` + strings.Join(streams, ",") + `]}`
+	return (&FakeRoundTripper{message: body, status: http.StatusOK}).RoundTrip(r)
+}
+
+func TestGetStreams(t *testing.T) {
+	var channelIDs []string
+	for i := 1; i <= 250; i++ {
+		channelIDs = append(channelIDs, strconv.Itoa(i))
+	}
+	// Duplicates are only requested once.
+	channelIDs = append(channelIDs, "2", "4")
+	rt := &channelStreamsRoundTripper{}
+	client := newTestClient(rt)
+	streams, err := client.GetStreams(channelIDs, StreamsOptions{Game: "Overwatch", StreamType: LiveStreams})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 125 {
+		t.Errorf("Expected 125 live streams.  Got %d.", len(streams))
+	}
+	if stream, ok := streams["42"]; !ok || stream.ID != "142" {
+		t.Errorf("Expected the stream of channel 42.  Got %#v.", stream)
+	}
+	if _, ok := streams["43"]; ok {
+		t.Error("Expected offline channel 43 to be left out.")
+	}
+	if len(rt.requests) != 3 {
+		t.Fatalf("Expected 3 requests.  Got %d.", len(rt.requests))
+	}
+	requested := 0
+	for _, req := range rt.requests {
+		q := req.URL.Query()
+		requested += len(strings.Split(q.Get("channel"), ","))
+		if q.Get("game") != "Overwatch" || q.Get("stream_type") != "live" || q.Get("language") != "" || q.Get("limit") != "100" {
+			t.Errorf("Unexpected query %s", req.URL.RawQuery)
+		}
+	}
+	if requested != 250 {
+		t.Errorf("Expected 250 requested channels.  Got %d.", requested)
+	}
+}
+
+func TestGetStreamsError(t *testing.T) {
+	var channelIDs []string
+	for i := 1; i <= 300; i++ {
+		channelIDs = append(channelIDs, strconv.Itoa(i))
+	}
+	rt := &channelStreamsRoundTripper{failID: "150"}
+	client := newTestClient(rt)
+	streams, err := client.GetStreams(channelIDs, StreamsOptions{})
+	if err == nil || streams != nil {
+		t.Fatalf("Expected an error and no streams.  Got %v and %d streams.", err, len(streams))
+	}
+	if apiErr, ok := AsError(err); !ok || apiErr.Status != http.StatusBadRequest {
+		t.Errorf("Expected the API error of the failing chunk.  Got %v.", err)
+	}
+}
+
+func TestGetStreamsNoChannels(t *testing.T) {
+	rt := &channelStreamsRoundTripper{}
+	client := newTestClient(rt)
+	streams, err := client.GetStreams(nil, StreamsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 0 || len(rt.requests) != 0 {
+		t.Errorf("Expected no streams and no requests.  Got %d and %d.", len(streams), len(rt.requests))
+	}
+}
+
+func TestSearchStreams(t *testing.T) {
+	jsonResponse := `{"_total": 1, "streams": [{"_id": 23937446096, "game": "Overwatch", "viewers": 2123, "channel": {"_id": 121059319, "name": "moonmoon_ow"}}]}`
+	var expected Streams
+	err := json.Unmarshal([]byte(jsonResponse), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	streams, err := client.SearchStreams("overwatch", 10, 0, Bool(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*streams, expected) {
+		t.Errorf("SearchStreams: Expected %#v.  Got %#v.", expected, *streams)
+	}
+	q := fakeRT.requests[0].URL.Query()
+	if q.Get("query") != "overwatch" || q.Get("hls") != "true" || q.Get("limit") != "10" {
+		t.Errorf("Unexpected query %s", fakeRT.requests[0].URL.RawQuery)
+	}
+	if _, err := client.SearchStreams("overwatch", 10, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fakeRT.requests[1].URL.Query()["hls"]; ok {
+		t.Error("Expected no hls parameter when hls is nil.")
+	}
+}
+
+func TestGetFeaturedStreams(t *testing.T) {
+	jsonResponse := `{
+  "featured": [
+    {
+      "image": "https://static-cdn.jtvnw.net/jtv_user_pictures/panel-8630136-image-ef4a1c4d1b0d9b3e-320-320.png",
+      "priority": 5,
+      "scheduled": true,
+      "sponsored": false,
+      "stream": {
+        "_id": 24552477024,
+        "game": "Dungeons & Dragons",
+        "viewers": 18530,
+        "channel": {"_id": 8630136, "name": "geekandsundry", "status": "Critical Role"}
+      },
+      "text": "<p>Critical Role is live!</p>",
+      "title": "Critical Role"
+    }
+  ]
+}`
+	var expected FeaturedStreams
+	err := json.Unmarshal([]byte(jsonResponse), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	featured, err := client.GetFeaturedStreams(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*featured, expected) {
+		t.Errorf("GetFeaturedStreams: Expected %#v.  Got %#v.", expected, *featured)
+	}
+	if f := featured.Featured[0]; f.Priority != 5 || !f.Scheduled || f.Sponsored || f.Stream.Channel.Name != "geekandsundry" {
+		t.Errorf("Unexpected featured stream %#v", f)
+	}
+	if limit := fakeRT.requests[0].URL.Query().Get("limit"); limit != "25" {
+		t.Errorf("Expected the default limit 25.  Got %s.", limit)
+	}
+}
+
+func TestGetStreamsSummary(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"channels": 1276, "viewers": 67184}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	summary, err := client.GetStreamsSummary("Overwatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := StreamsSummary{Channels: 1276, Viewers: 67184}
+	if *summary != expected {
+		t.Errorf("GetStreamsSummary: Expected %#v.  Got %#v.", expected, *summary)
+	}
+	if game := fakeRT.requests[0].URL.Query().Get("game"); game != "Overwatch" {
+		t.Errorf("Expected %#v.  Got %#v.", "Overwatch", game)
+	}
+	if _, err := client.GetStreamsSummary(""); err != nil {
+		t.Fatal(err)
+	}
+	if fakeRT.requests[1].URL.RawQuery != "" {
+		t.Errorf("Expected no query for the overall summary.  Got %s.", fakeRT.requests[1].URL.RawQuery)
+	}
+}
@@ -0,0 +1,115 @@
+package twitch2go
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+// GetChannelTeams returns the teams the given channel belongs to.
+func (c *Client) GetChannelTeams(channelID string) ([]Team, error) {
+	return c.GetChannelTeamsContext(context.Background(), channelID)
+}
+
+// GetChannelTeamsContext is like GetChannelTeams but uses the given context for the request.
+func (c *Client) GetChannelTeamsContext(ctx context.Context, channelID string) ([]Team, error) {
+	url := "/channels/" + channelID + "/teams"
+	// Do the request
+	resp, err := c.do("GET", url, &doOptions{context: ctx})
+	if err != nil {
+		return nil, errors.Annotate(err, "GetChannelTeams")
+	}
+	defer resp.Body.Close()
+	teams := &Teams{}
+	err = json.NewDecoder(resp.Body).Decode(teams)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return teams.Teams, nil
+}
+
+/*
+GetTeams returns all active teams, without their members.
+
+The function takes in two parameters:
+
+	limit:
+		The number of teams to return.  Max is 100, default is 25.
+
+	offset:
+		The offset in the list to return.  A page with fewer than limit teams is the last one.
+*/
+func (c *Client) GetTeams(limit int, offset int) ([]Team, error) {
+	return c.GetTeamsContext(context.Background(), limit, offset)
+}
+
+// GetTeamsContext is like GetTeams but uses the given context for the request.
+func (c *Client) GetTeamsContext(ctx context.Context, limit int, offset int) ([]Team, error) {
+	limit = pageLimit(limit, 25)
+	opts := &doOptions{
+		params: map[string]string{
+			"limit":  strconv.Itoa(limit),
+			"offset": strconv.Itoa(offset),
+		},
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("GET", "/teams", opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetTeams")
+	}
+	defer resp.Body.Close()
+	teams := &Teams{}
+	err = json.NewDecoder(resp.Body).Decode(teams)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return teams.Teams, nil
+}
+
+// GetTeam returns the team with the given name, including its member channels.
+func (c *Client) GetTeam(name string) (*Team, error) {
+	return c.GetTeamContext(context.Background(), name)
+}
+
+// GetTeamContext is like GetTeam but uses the given context for the request.
+func (c *Client) GetTeamContext(ctx context.Context, name string) (*Team, error) {
+	url := "/teams/" + name
+	// Do the request
+	resp, err := c.do("GET", url, &doOptions{context: ctx})
+	if err != nil {
+		return nil, errors.Annotate(err, "GetTeam")
+	}
+	defer resp.Body.Close()
+	team := &Team{}
+	err = json.NewDecoder(resp.Body).Decode(team)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return team, nil
+}
+
+// GetLiveTeamMembers returns the streams of the members of the given team that are live, keyed by channel ID.  It
+// fetches the team and then the streams of all its members, see GetStreams.
+func (c *Client) GetLiveTeamMembers(name string) (map[string]Stream, error) {
+	return c.GetLiveTeamMembersContext(context.Background(), name)
+}
+
+// GetLiveTeamMembersContext is like GetLiveTeamMembers but uses the given context for the requests.
+func (c *Client) GetLiveTeamMembersContext(ctx context.Context, name string) (map[string]Stream, error) {
+	team, err := c.GetTeamContext(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	channelIDs := make([]string, 0, len(team.Channels))
+	for _, ch := range team.Channels {
+		channelIDs = append(channelIDs, ch.ID.String())
+	}
+	streams, err := c.GetStreamsContext(ctx, channelIDs, StreamsOptions{})
+	if err != nil {
+		return nil, errors.Annotate(err, "GetLiveTeamMembers")
+	}
+	return streams, nil
+}
@@ -0,0 +1,105 @@
+package twitch2go
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const teamJSON = `{
+  "_id": 10,
+  "background": null,
+  "banner": "https://static-cdn.jtvnw.net/jtv_user_pictures/team-staff-banner_image-1e028e16d8fb3a3d-640x125.png",
+  "created_at": "2011-01-20T16:33:59Z",
+  "display_name": "Twitch Staff",
+  "info": "Twitch staff stream here.",
+  "logo": "https://static-cdn.jtvnw.net/jtv_user_pictures/team-staff-team_logo_image-76418c0c93a9d48b-300x300.png",
+  "name": "staff",
+  "updated_at": "2016-12-14T18:48:33Z",
+  "users": [
+    {"_id": 5582097, "name": "aoicoffee", "display_name": "AoiCoffee", "status": "Coffee time"},
+    {"_id": 6391593, "name": "chosenken", "display_name": "Chosenken", "status": "Overwatch"}
+  ]
+}`
+
+func TestGetTeam(t *testing.T) {
+	var expected Team
+	err := json.Unmarshal([]byte(teamJSON), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: teamJSON, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	team, err := client.GetTeam("staff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*team, expected) {
+		t.Errorf("GetTeam: Expected %#v.  Got %#v.", expected, *team)
+	}
+	if len(team.Channels) != 2 || team.Channels[1].Name != "chosenken" {
+		t.Errorf("Expected the member channels.  Got %#v.", team.Channels)
+	}
+}
+
+func TestGetChannelTeams(t *testing.T) {
+	jsonResponse := `{"teams": [{"_id": 10, "name": "staff", "display_name": "Twitch Staff"}]}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	teams, err := client.GetChannelTeams("6391593")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 1 || teams[0].Name != "staff" || teams[0].Channels != nil {
+		t.Errorf("Unexpected teams %#v", teams)
+	}
+	if path := fakeRT.requests[0].URL.Path; path != "/kraken/channels/6391593/teams" {
+		t.Errorf("Unexpected path %s", path)
+	}
+}
+
+func TestGetTeams(t *testing.T) {
+	jsonResponse := `{"teams": [{"_id": 10, "name": "staff"}, {"_id": 11, "name": "esl"}]}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	teams, err := client.GetTeams(200, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 2 {
+		t.Errorf("Expected 2 teams.  Got %d.", len(teams))
+	}
+	q := fakeRT.requests[0].URL.Query()
+	if q.Get("limit") != "100" || q.Get("offset") != "50" {
+		t.Errorf("Unexpected query %s", fakeRT.requests[0].URL.RawQuery)
+	}
+}
+
+// pathRoundTripper answers requests with the canned body for their path.
+type pathRoundTripper map[string]string
+
+func (rt pathRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, ok := rt[r.URL.Path]
+	if !ok {
+		return (&FakeRoundTripper{message: `{"error":"Not Found","status":404,"message":"not found"}`, status: http.StatusNotFound}).RoundTrip(r)
+	}
+	return (&FakeRoundTripper{message: body, status: http.StatusOK}).RoundTrip(r)
+}
+
+func TestGetLiveTeamMembers(t *testing.T) {
+	client := newTestClient(pathRoundTripper{
+		"/kraken/teams/staff": teamJSON,
+		"/kraken/streams":     `{"_total": 1, "streams": [{"_id": 1, "game": "Overwatch", "channel": {"_id": 6391593, "name": "chosenken"}}]}`,
+	})
+	streams, err := client.GetLiveTeamMembers("staff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 1 || streams["6391593"].Game != "Overwatch" {
+		t.Errorf("Expected only chosenken to be live.  Got %#v.", streams)
+	}
+	if _, err := client.GetLiveTeamMembers("nobody"); !IsNotFound(err) {
+		t.Errorf("Expected a not found error.  Got %v.", err)
+	}
+}
@@ -0,0 +1,170 @@
+package twitch2go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/juju/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Endpoint is the Twitch OAuth2 endpoint, for use with golang.org/x/oauth2.
+var Endpoint = oauth2.Endpoint{
+	AuthURL:   "https://id.twitch.tv/oauth2/authorize",
+	TokenURL:  "https://id.twitch.tv/oauth2/token",
+	AuthStyle: oauth2.AuthStyleInParams,
+}
+
+// validateURL is the endpoint used by ValidateToken.
+var validateURL = "https://id.twitch.tv/oauth2/validate"
+
+// TokenSource supplies OAuth tokens to a Client.  It has the same method set as oauth2.TokenSource, so any token
+// source from golang.org/x/oauth2 can be used and the token sources returned here can be used with oauth2.
+type TokenSource interface {
+	Token() (*oauth2.Token, error)
+}
+
+// Invalidator is implemented by token sources that can discard their current token.  When a request made with a token
+// from such a source is rejected with 401 Unauthorized, the Client invalidates the token and retries the request once
+// with a fresh one.
+type Invalidator interface {
+	Invalidate()
+}
+
+// refreshingTokenSource caches a token and fetches a new one when it expires or is invalidated.
+type refreshingTokenSource struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+	// fetch returns a new token.  current is the cached token, which may be nil or invalid.
+	fetch func(current *oauth2.Token) (*oauth2.Token, error)
+}
+
+func (s *refreshingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.Valid() {
+		return s.token, nil
+	}
+	token, err := s.fetch(s.token)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error fetching token")
+	}
+	s.token = token
+	return token, nil
+}
+
+func (s *refreshingTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil {
+		// Keep the refresh token around, only the access token is discarded.
+		invalid := *s.token
+		invalid.AccessToken = ""
+		s.token = &invalid
+	}
+}
+
+// NewAppTokenSource returns a TokenSource of app access tokens obtained with the client credentials flow.  A new token
+// is requested when the current one expires or is rejected by the API.
+func NewAppTokenSource(ctx context.Context, clientID string, clientSecret string, scopes ...string) TokenSource {
+	config := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     Endpoint.TokenURL,
+		Scopes:       scopes,
+		AuthStyle:    Endpoint.AuthStyle,
+	}
+	return &refreshingTokenSource{
+		fetch: func(*oauth2.Token) (*oauth2.Token, error) {
+			return config.Token(ctx)
+		},
+	}
+}
+
+// NewUserTokenSource returns a TokenSource for a user access token.  token must carry a refresh token, which is used
+// to get a new access token when the current one expires or is rejected by the API.
+func NewUserTokenSource(ctx context.Context, clientID string, clientSecret string, token *oauth2.Token) TokenSource {
+	config := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint:     Endpoint,
+	}
+	return &refreshingTokenSource{
+		token: token,
+		fetch: func(current *oauth2.Token) (*oauth2.Token, error) {
+			if current == nil || current.RefreshToken == "" {
+				return nil, errors.New("no refresh token")
+			}
+			// The oauth2 token source refreshes because the token it is given has no access token.
+			return config.TokenSource(ctx, &oauth2.Token{RefreshToken: current.RefreshToken}).Token()
+		},
+	}
+}
+
+// StaticTokenSource returns a TokenSource that always returns the given access token.
+func StaticTokenSource(accessToken string) TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken})
+}
+
+// withToken performs a request with a token from source.  If the token is rejected with 401 Unauthorized and source
+// is an Invalidator, the token is invalidated and the request is performed once more with a fresh token.
+func withToken(source TokenSource, do func(token string) (*http.Response, error)) (*http.Response, error) {
+	token, err := source.Token()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := do(token.AccessToken)
+	invalidator, ok := source.(Invalidator)
+	if !ok || !IsUnauthorized(err) {
+		return resp, err
+	}
+	invalidator.Invalidate()
+	token, err = source.Token()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return do(token.AccessToken)
+}
+
+// TokenInfo describes an access token, as returned by ValidateToken.
+type TokenInfo struct {
+	ClientID string   `json:"client_id"`
+	Login    string   `json:"login"`
+	UserID   string   `json:"user_id"`
+	Scopes   []string `json:"scopes"`
+	// ExpiresIn is the number of seconds the token stays valid.
+	ExpiresIn int `json:"expires_in"`
+}
+
+// ValidateToken checks the given access token with Twitch and returns what it grants.  An invalid or expired token
+// gives an error for which IsUnauthorized is true.
+func (c *Client) ValidateToken(token string) (*TokenInfo, error) {
+	return c.ValidateTokenContext(context.Background(), token)
+}
+
+// ValidateTokenContext is like ValidateToken but uses the given context for the request.
+func (c *Client) ValidateTokenContext(ctx context.Context, token string) (*TokenInfo, error) {
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", validateURL, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("OAuth %s", token))
+		return req, nil
+	}
+	resp, err := send(ctx, c.HTTPClient, c.RetryPolicy, nil, c.logger, "GET", newRequest)
+	if err != nil {
+		return nil, errors.Annotate(err, "ValidateToken")
+	}
+	defer resp.Body.Close()
+	info := &TokenInfo{}
+	err = json.NewDecoder(resp.Body).Decode(info)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return info, nil
+}
@@ -0,0 +1,174 @@
+package twitch2go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// countingTokenSource hands out token-1, token-2, ... and counts invalidations.
+type countingTokenSource struct {
+	issued      int
+	invalidated int
+	current     *oauth2.Token
+}
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	if s.current == nil {
+		s.issued++
+		s.current = &oauth2.Token{AccessToken: fmt.Sprintf("token-%d", s.issued)}
+	}
+	return s.current, nil
+}
+
+func (s *countingTokenSource) Invalidate() {
+	s.invalidated++
+	s.current = nil
+}
+
+// authRoundTripper rejects every token except the accepted one with 401 Unauthorized.
+type authRoundTripper struct {
+	accepted string
+	seen     []string
+}
+
+func (rt *authRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	auth := r.Header.Get("Authorization")
+	rt.seen = append(rt.seen, auth)
+	if auth == "OAuth "+rt.accepted || auth == "Bearer "+rt.accepted {
+		return (&FakeRoundTripper{message: `{"_total": 0, "subscriptions": []}`, status: http.StatusOK}).RoundTrip(r)
+	}
+	return (&FakeRoundTripper{message: `{"error":"Unauthorized","status":401,"message":"invalid oauth token"}`, status: http.StatusUnauthorized}).RoundTrip(r)
+}
+
+func TestTokenSourceUsedWithoutOAuth(t *testing.T) {
+	rt := &authRoundTripper{accepted: "token-1"}
+	client := newTestClient(rt)
+	client.TokenSource = &countingTokenSource{}
+	if _, err := client.GetChannelSubscribers("123456", "", 25, 0, ASC); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetChannelSubscribers("123456", "explicit", 25, 0, ASC); !IsUnauthorized(err) {
+		t.Errorf("Expected an explicit oauth argument to win over the token source.  Got %v.", err)
+	}
+	if fmt.Sprint(rt.seen) != "[OAuth token-1 OAuth explicit]" {
+		t.Errorf("Unexpected Authorization headers %q.", rt.seen)
+	}
+}
+
+func TestTokenRefreshedOnUnauthorized(t *testing.T) {
+	rt := &authRoundTripper{accepted: "token-2"}
+	source := &countingTokenSource{}
+	client := newTestClient(rt)
+	client.TokenSource = source
+	if _, err := client.GetChannelSubscribers("123456", "", 25, 0, ASC); err != nil {
+		t.Fatal(err)
+	}
+	if source.invalidated != 1 || source.issued != 2 {
+		t.Errorf("Expected one invalidation and two tokens.  Got %d and %d.", source.invalidated, source.issued)
+	}
+	rt.accepted = "never"
+	if _, err := client.GetChannelSubscribers("123456", "", 25, 0, ASC); !IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error after a single refresh.  Got %v.", err)
+	}
+	if source.issued != 3 {
+		t.Errorf("Expected a single refresh per request.  Got %d tokens.", source.issued)
+	}
+}
+
+func TestHelixTokenSource(t *testing.T) {
+	rt := &authRoundTripper{accepted: "token-2"}
+	client := newTestHelixClient(rt)
+	client.AccessToken = ""
+	client.TokenSource = &countingTokenSource{}
+	if _, err := client.GetBroadcasterSubscriptions(context.Background(), "123456", nil, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(rt.seen) != "[Bearer token-1 Bearer token-2]" {
+		t.Errorf("Unexpected Authorization headers %q.", rt.seen)
+	}
+}
+
+func TestAppTokenSource(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "id" || r.Form.Get("client_secret") != "secret" {
+			t.Errorf("Unexpected token request %v.", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"app-%d","expires_in":3600,"token_type":"bearer"}`, requests)
+	}))
+	defer srv.Close()
+	defer func(tokenURL string) { Endpoint.TokenURL = tokenURL }(Endpoint.TokenURL)
+	Endpoint.TokenURL = srv.URL
+
+	source := NewAppTokenSource(context.Background(), "id", "secret")
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "app-1" {
+			t.Errorf("Expected the cached token app-1.  Got %q.", token.AccessToken)
+		}
+	}
+	source.(Invalidator).Invalidate()
+	token, err := source.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "app-2" {
+		t.Errorf("Expected a new token after invalidation.  Got %q.", token.AccessToken)
+	}
+}
+
+func TestUserTokenSourceRefreshes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "refresh-1" {
+			t.Errorf("Unexpected token request %v.", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"user-2","refresh_token":"refresh-2","expires_in":3600,"token_type":"bearer"}`)
+	}))
+	defer srv.Close()
+	defer func(tokenURL string) { Endpoint.TokenURL = tokenURL }(Endpoint.TokenURL)
+	Endpoint.TokenURL = srv.URL
+
+	expired := &oauth2.Token{AccessToken: "user-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(-time.Hour)}
+	source := NewUserTokenSource(context.Background(), "id", "secret", expired)
+	token, err := source.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "user-2" || token.RefreshToken != "refresh-2" {
+		t.Errorf("Expected the refreshed token.  Got %#v.", token)
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: `{"client_id":"wbmytr93xzw8zbg0p1izqyzzc5mbiz","login":"twitchdev","scopes":["channel:read:subscriptions"],"user_id":"141981764","expires_in":5520838}`, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	info, err := client.ValidateToken("fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Login != "twitchdev" || info.UserID != "141981764" || len(info.Scopes) != 1 || info.ExpiresIn != 5520838 {
+		t.Errorf("Unexpected token info %#v.", info)
+	}
+	if auth := fakeRT.requests[0].Header.Get("Authorization"); auth != "OAuth fakeoauth" {
+		t.Errorf("Expected the token in the Authorization header.  Got %q.", auth)
+	}
+	fakeRT = &FakeRoundTripper{message: `{"status":401,"message":"invalid access token"}`, status: http.StatusUnauthorized}
+	client = newTestClient(fakeRT)
+	if _, err := client.ValidateToken("expired"); !IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error.  Got %v.", err)
+	}
+}
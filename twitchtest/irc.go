@@ -0,0 +1,296 @@
+package twitchtest
+
+import (
+	"bufio"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/websocket"
+)
+
+// IRCServer is a fake Twitch chat server speaking IRC over TCP and WebSocket.  It logs users in, acknowledges
+// capabilities, echoes JOIN and PART with the channel's ROOMSTATE, sends USERSTATE on join and after every PRIVMSG and
+// answers PING.  Everything it receives is recorded for WaitFor, and Send pushes lines to the connected clients.
+type IRCServer struct {
+	listener net.Listener
+	ws       *httptest.Server
+
+	mu        sync.Mutex
+	cond      *sync.Cond
+	users     map[string]string
+	mods      map[string]bool
+	peers     map[*ircPeer]bool
+	received  []string
+	dropPings bool
+	closed    bool
+}
+
+// NewIRCServer starts and returns a fake chat server.  Call Close when done.
+func NewIRCServer() *IRCServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("twitchtest: listening: " + err.Error())
+	}
+	s := &IRCServer{
+		listener: listener,
+		users:    map[string]string{},
+		mods:     map[string]bool{},
+		peers:    map[*ircPeer]bool{},
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.ws = httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		s.serve(&ircPeer{ws: ws})
+	}))
+	go s.accept()
+	return s
+}
+
+// URL returns the irc:// address of the server.
+func (s *IRCServer) URL() string {
+	return "irc://" + s.listener.Addr().String()
+}
+
+// WebSocketURL returns the ws:// address of the server.
+func (s *IRCServer) WebSocketURL() string {
+	return "ws://" + strings.TrimPrefix(s.ws.URL, "http://")
+}
+
+// AddUser registers a login and its oauth token, without the `oauth:` prefix.  As long as no user is added the server
+// accepts any login.
+func (s *IRCServer) AddUser(login string, oauth string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[strings.ToLower(login)] = oauth
+}
+
+// AddModerator makes the given login a moderator of the given channel, as reported by USERSTATE.
+func (s *IRCServer) AddModerator(channel string, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mods[strings.ToLower(channel)+"/"+strings.ToLower(login)] = true
+}
+
+// userState returns the USERSTATE line of login in channel.
+func (s *IRCServer) userState(channel string, login string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mods[strings.TrimPrefix(channel, "#")+"/"+login] {
+		return "@badges=moderator/1;display-name=" + login + ";mod=1 :tmi.twitch.tv USERSTATE " + channel
+	}
+	return "@badges=;display-name=" + login + ";mod=0 :tmi.twitch.tv USERSTATE " + channel
+}
+
+// DropPings makes the server ignore the PINGs of its clients, like a dead connection would.
+func (s *IRCServer) DropPings(drop bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropPings = drop
+}
+
+// Send sends a raw IRC line to every logged in client.
+func (s *IRCServer) Send(line string) {
+	s.mu.Lock()
+	peers := make([]*ircPeer, 0, len(s.peers))
+	for p := range s.peers {
+		peers = append(peers, p)
+	}
+	s.mu.Unlock()
+	for _, p := range peers {
+		p.writeLine(line)
+	}
+}
+
+/*
+WaitFor waits until the server has received a line starting with prefix and returns it.  Each received line is
+returned at most once, so waiting twice for `JOIN` waits for two JOINs.
+
+WaitFor fails if no such line arrives within timeout.
+*/
+func (s *IRCServer) WaitFor(prefix string, timeout time.Duration) (string, error) {
+	timer := time.AfterFunc(timeout, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.cond.Broadcast()
+	})
+	defer timer.Stop()
+	deadline := time.Now().Add(timeout)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		for i, line := range s.received {
+			if strings.HasPrefix(line, prefix) {
+				s.received = append(s.received[:i:i], s.received[i+1:]...)
+				return line, nil
+			}
+		}
+		if s.closed || !time.Now().Before(deadline) {
+			return "", errors.Errorf("twitchtest: no %q received within %v", prefix, timeout)
+		}
+		s.cond.Wait()
+	}
+}
+
+// Disconnect drops every client connection, like a server restart would.
+func (s *IRCServer) Disconnect() {
+	s.mu.Lock()
+	peers := s.peers
+	s.peers = map[*ircPeer]bool{}
+	s.mu.Unlock()
+	for p := range peers {
+		p.close()
+	}
+}
+
+// Close disconnects the clients and stops the server.
+func (s *IRCServer) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	s.listener.Close()
+	s.Disconnect()
+	s.ws.Close()
+}
+
+func (s *IRCServer) accept() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serve(&ircPeer{conn: c, r: bufio.NewReader(c)})
+	}
+}
+
+// serve runs a client connection until it is closed.
+func (s *IRCServer) serve(p *ircPeer) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.peers, p)
+		s.mu.Unlock()
+		p.close()
+	}()
+	var pass, nick string
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		if !strings.HasPrefix(line, "PASS ") {
+			s.received = append(s.received, line)
+			s.cond.Broadcast()
+		}
+		dropPings := s.dropPings
+		s.mu.Unlock()
+
+		command, params := line, ""
+		if i := strings.IndexByte(line, ' '); i >= 0 && strings.HasPrefix(line, "@") {
+			// Skip the tags of replies.
+			command = line[i+1:]
+		}
+		if i := strings.IndexByte(command, ' '); i >= 0 {
+			command, params = command[:i], command[i+1:]
+		}
+		switch command {
+		case "CAP":
+			p.writeLine(":tmi.twitch.tv CAP * ACK " + strings.TrimPrefix(params, "REQ "))
+		case "PASS":
+			pass = strings.TrimPrefix(params, "oauth:")
+		case "NICK":
+			nick = strings.ToLower(params)
+			if !s.login(p, nick, pass) {
+				p.writeLine(":tmi.twitch.tv NOTICE * :Login authentication failed")
+				return
+			}
+			p.writeLine(":tmi.twitch.tv 001 " + nick + " :Welcome, GLHF!")
+		case "PING":
+			if !dropPings {
+				p.writeLine(":tmi.twitch.tv PONG tmi.twitch.tv " + params)
+			}
+		case "JOIN":
+			for _, channel := range strings.Split(params, ",") {
+				p.writeLine(":" + nick + "!" + nick + "@" + nick + ".tmi.twitch.tv JOIN " + channel)
+				p.writeLine(s.userState(channel, nick))
+				p.writeLine("@emote-only=0;followers-only=-1;r9k=0;slow=0;subs-only=0 :tmi.twitch.tv ROOMSTATE " + channel)
+			}
+		case "PRIVMSG":
+			if i := strings.IndexByte(params, ' '); i >= 0 {
+				p.writeLine(s.userState(params[:i], nick))
+			}
+		case "PART":
+			for _, channel := range strings.Split(params, ",") {
+				p.writeLine(":" + nick + "!" + nick + "@" + nick + ".tmi.twitch.tv PART " + channel)
+			}
+		}
+	}
+}
+
+// login checks the credentials and registers p as a logged in client.
+func (s *IRCServer) login(p *ircPeer, nick string, pass string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.users) > 0 {
+		if oauth, ok := s.users[nick]; !ok || oauth != pass {
+			return false
+		}
+	}
+	s.peers[p] = true
+	return true
+}
+
+// ircPeer is the server side of a client connection, over TCP or WebSocket.
+type ircPeer struct {
+	conn    net.Conn
+	r       *bufio.Reader
+	ws      *websocket.Conn
+	pending []string
+	mu      sync.Mutex
+}
+
+func (p *ircPeer) readLine() (string, error) {
+	if p.ws == nil {
+		line, err := p.r.ReadString('\n')
+		return strings.TrimRight(line, "\r\n"), err
+	}
+	for len(p.pending) == 0 {
+		var frame string
+		if err := websocket.Message.Receive(p.ws, &frame); err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(frame, "\n") {
+			if line = strings.TrimRight(line, "\r"); line != "" {
+				p.pending = append(p.pending, line)
+			}
+		}
+	}
+	line := p.pending[0]
+	p.pending = p.pending[1:]
+	return line, nil
+}
+
+func (p *ircPeer) writeLine(line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ws == nil {
+		p.conn.Write([]byte(line + "\r\n"))
+		return
+	}
+	websocket.Message.Send(p.ws, line+"\r\n")
+}
+
+func (p *ircPeer) close() {
+	if p.ws == nil {
+		p.conn.Close()
+		return
+	}
+	p.ws.Close()
+}
@@ -0,0 +1,243 @@
+package twitchtest
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/websocket"
+)
+
+// pubsubFrame is a message exchanged with PubSub clients.
+type pubsubFrame struct {
+	Type  string           `json:"type"`
+	Nonce string           `json:"nonce,omitempty"`
+	Error *string          `json:"error,omitempty"`
+	Data  *pubsubFrameData `json:"data,omitempty"`
+}
+
+type pubsubFrameData struct {
+	Topics    []string `json:"topics,omitempty"`
+	AuthToken string   `json:"auth_token,omitempty"`
+	Topic     string   `json:"topic,omitempty"`
+	Message   string   `json:"message,omitempty"`
+}
+
+// PubSubServer is a fake Twitch PubSub server.  It answers LISTEN, UNLISTEN and PING, and Publish pushes messages to
+// the clients listening to a topic.
+type PubSubServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	cond      *sync.Cond
+	tokens    map[string]bool
+	peers     map[*pubsubPeer]bool
+	pings     int
+	dropPings bool
+	closed    bool
+}
+
+type pubsubPeer struct {
+	ws     *websocket.Conn
+	topics map[string]bool
+}
+
+// NewPubSubServer starts and returns a fake PubSub server.  Call Close when done.
+func NewPubSubServer() *PubSubServer {
+	s := &PubSubServer{
+		tokens: map[string]bool{},
+		peers:  map[*pubsubPeer]bool{},
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.Server = httptest.NewServer(websocket.Handler(s.serve))
+	return s
+}
+
+// URL returns the ws:// address of the server.
+func (s *PubSubServer) URL() string {
+	return "ws://" + strings.TrimPrefix(s.Server.URL, "http://")
+}
+
+// AddToken registers an oauth token.  As long as no token is added the server accepts any token.
+func (s *PubSubServer) AddToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token] = true
+}
+
+// DropPings makes the server ignore the PINGs of its clients, like a dead connection would.
+func (s *PubSubServer) DropPings(drop bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropPings = drop
+}
+
+// Publish sends message to the clients listening to topic and returns how many there were.  A string or []byte message
+// is sent as is, anything else is encoded to JSON.
+func (s *PubSubServer) Publish(topic string, message interface{}) int {
+	var text string
+	switch m := message.(type) {
+	case string:
+		text = m
+	case []byte:
+		text = string(m)
+	default:
+		b, err := json.Marshal(message)
+		if err != nil {
+			panic("twitchtest: encoding message: " + err.Error())
+		}
+		text = string(b)
+	}
+	f := pubsubFrame{Type: "MESSAGE", Data: &pubsubFrameData{Topic: topic, Message: text}}
+	n := 0
+	for _, p := range s.listeners(topic) {
+		if websocket.JSON.Send(p.ws, f) == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *PubSubServer) listeners(topic string) []*pubsubPeer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var peers []*pubsubPeer
+	for p := range s.peers {
+		if p.topics[topic] {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// Reconnect asks every client to reconnect, like Twitch does before maintenance.
+func (s *PubSubServer) Reconnect() {
+	s.mu.Lock()
+	peers := make([]*pubsubPeer, 0, len(s.peers))
+	for p := range s.peers {
+		peers = append(peers, p)
+	}
+	s.mu.Unlock()
+	for _, p := range peers {
+		websocket.JSON.Send(p.ws, pubsubFrame{Type: "RECONNECT"})
+	}
+}
+
+// Disconnect drops every client connection.
+func (s *PubSubServer) Disconnect() {
+	s.mu.Lock()
+	peers := s.peers
+	s.peers = map[*pubsubPeer]bool{}
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	for p := range peers {
+		p.ws.Close()
+	}
+}
+
+// WaitForListen waits until a connected client listens to topic, or fails after timeout.
+func (s *PubSubServer) WaitForListen(topic string, timeout time.Duration) error {
+	return s.waitUntil(timeout, func() bool {
+		for p := range s.peers {
+			if p.topics[topic] {
+				return true
+			}
+		}
+		return false
+	}, "listen to "+topic)
+}
+
+// WaitForPing waits until a client pings the server, or fails after timeout.  Each ping is waited for once.
+func (s *PubSubServer) WaitForPing(timeout time.Duration) error {
+	return s.waitUntil(timeout, func() bool {
+		if s.pings > 0 {
+			s.pings--
+			return true
+		}
+		return false
+	}, "ping")
+}
+
+// waitUntil waits until done returns true, calling it with s.mu held.
+func (s *PubSubServer) waitUntil(timeout time.Duration, done func() bool, what string) error {
+	timer := time.AfterFunc(timeout, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.cond.Broadcast()
+	})
+	defer timer.Stop()
+	deadline := time.Now().Add(timeout)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !done() {
+		if s.closed || !time.Now().Before(deadline) {
+			return errors.Errorf("twitchtest: no %s within %v", what, timeout)
+		}
+		s.cond.Wait()
+	}
+	return nil
+}
+
+// Close disconnects the clients and stops the server.
+func (s *PubSubServer) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	s.Disconnect()
+	s.Server.Close()
+}
+
+// serve runs a client connection until it is closed.
+func (s *PubSubServer) serve(ws *websocket.Conn) {
+	p := &pubsubPeer{ws: ws, topics: map[string]bool{}}
+	s.mu.Lock()
+	s.peers[p] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.peers, p)
+		s.cond.Broadcast()
+		s.mu.Unlock()
+		ws.Close()
+	}()
+	for {
+		var f pubsubFrame
+		if err := websocket.JSON.Receive(ws, &f); err != nil {
+			return
+		}
+		s.mu.Lock()
+		var reply *pubsubFrame
+		switch f.Type {
+		case "PING":
+			s.pings++
+			if !s.dropPings {
+				reply = &pubsubFrame{Type: "PONG"}
+			}
+		case "LISTEN", "UNLISTEN":
+			var errText string
+			switch {
+			case f.Data == nil || len(f.Data.Topics) == 0:
+				errText = "ERR_BADMESSAGE"
+			case f.Type == "LISTEN" && len(s.tokens) > 0 && !s.tokens[f.Data.AuthToken]:
+				errText = "ERR_BADAUTH"
+			default:
+				for _, topic := range f.Data.Topics {
+					p.topics[topic] = f.Type == "LISTEN"
+				}
+			}
+			reply = &pubsubFrame{Type: "RESPONSE", Nonce: f.Nonce, Error: &errText}
+		default:
+			errText := "ERR_BADMESSAGE"
+			reply = &pubsubFrame{Type: "RESPONSE", Nonce: f.Nonce, Error: &errText}
+		}
+		s.cond.Broadcast()
+		s.mu.Unlock()
+		if reply != nil {
+			websocket.JSON.Send(ws, reply)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package twitchtest
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+)
+
+// IDs, names and tokens of the data loaded by Seed.
+const (
+	// SeedChannelID is the ID of the seeded broadcaster, who has followers, subscribers, videos, editors and chatters.
+	SeedChannelID = "6391593"
+	// SeedChannelName is the name of the seeded broadcaster.
+	SeedChannelName = "chosenken"
+	// SeedOAuth is an oauth token belonging to the seeded broadcaster.
+	SeedOAuth = "twitchtest-oauth-chosenken"
+	// SeedLiveChannelID is the ID of a seeded channel that is live.
+	SeedLiveChannelID = "26610234"
+	// SeedLiveChannelName is the name of the seeded live channel.
+	SeedLiveChannelName = "cohhcarnage"
+	// SeedFollowerCount is the number of users following the seeded broadcaster.
+	SeedFollowerCount = 150
+	// SeedSubscriberCount is the number of users subscribed to the seeded broadcaster.
+	SeedSubscriberCount = 30
+	// SeedVideoCount is the number of videos on the seeded broadcaster's channel.
+	SeedVideoCount = 12
+)
+
+// seedTime is the reference time of the seeded data.
+var seedTime = time.Date(2017, 2, 10, 19, 0, 0, 0, time.UTC)
+
+// NewSeededServer starts a fake server loaded with the data described by the Seed constants.
+func NewSeededServer() *Server {
+	s := NewServer()
+	s.Seed()
+	return s
+}
+
+// Seed loads a small, deterministic data set: the broadcaster SeedChannelName with SeedFollowerCount followers,
+// SeedSubscriberCount subscribers, SeedVideoCount videos, an editor and chatters, and the live channel
+// SeedLiveChannelName, which the broadcaster follows.
+func (s *Server) Seed() {
+	s.addAccount(SeedChannelID, SeedChannelName, "Chosenken", "Grand Theft Auto V", seedTime.AddDate(-7, 0, 0))
+	s.addAccount(SeedLiveChannelID, SeedLiveChannelName, "CohhCarnage", "For Honor", seedTime.AddDate(-5, 0, 0))
+	s.AddToken(SeedOAuth, SeedChannelID)
+	s.SetStream(SeedLiveChannelID, twitch2go.Stream{
+		ID:          "24487762416",
+		Game:        "For Honor",
+		Viewers:     5350,
+		VideoHeight: 1080,
+		AverageFps:  60,
+		CreatedAt:   seedTime,
+	})
+	s.AddFollow(SeedChannelID, SeedLiveChannelID, seedTime.AddDate(-1, 0, 0), true)
+
+	for i := 0; i < SeedFollowerCount; i++ {
+		id := strconv.Itoa(100000 + i)
+		name := "follower" + strconv.Itoa(i)
+		s.addAccount(id, name, name, "", seedTime.AddDate(0, 0, -i))
+		s.AddFollow(id, SeedChannelID, seedTime.Add(-time.Duration(i)*time.Hour), i%2 == 0)
+		if i < SeedSubscriberCount {
+			s.AddSubscription(id, SeedChannelID, seedTime.Add(-time.Duration(i)*24*time.Hour))
+		}
+	}
+	s.AddEditor(SeedChannelID, "100000")
+	for i := 0; i < SeedVideoCount; i++ {
+		s.AddVideo(SeedChannelID, twitch2go.Video{
+			ID:            "v" + strconv.Itoa(1000+i),
+			BroadcastID:   json.Number(strconv.Itoa(20000 + i)),
+			BroadcastType: "archive",
+			CreatedAt:     seedTime.Add(-time.Duration(i) * 24 * time.Hour),
+			Game:          "Grand Theft Auto V",
+			Language:      "en",
+			Length:        3600,
+			Title:         "Past broadcast " + strconv.Itoa(i),
+			Views:         uint(10 * i),
+		})
+	}
+	s.SetChatters(SeedChannelName, twitch2go.Chatters{
+		Moderators: []string{"follower0", "nightbot"},
+		Viewers:    []string{"follower1", "follower2", "follower3"},
+	})
+}
+
+// addAccount seeds a user and its channel.
+func (s *Server) addAccount(id string, name string, displayName string, game string, createdAt time.Time) {
+	s.AddUser(twitch2go.User{
+		ID:          json.Number(id),
+		Name:        name,
+		DisplayName: displayName,
+		Type:        "user",
+		CreatedAt:   createdAt,
+		UpdatedAt:   seedTime,
+	})
+	s.AddChannel(twitch2go.Channel{
+		ID:          json.Number(id),
+		Name:        name,
+		DisplayName: displayName,
+		Game:        game,
+		Language:    "en",
+		CreatedAt:   createdAt,
+		UpdatedAt:   seedTime,
+		URL:         "https://www.twitch.tv/" + name,
+	})
+}
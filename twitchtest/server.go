@@ -0,0 +1,734 @@
+/*
+Package twitchtest provides an in-process fake of the Twitch Kraken v5 API and the TMI chatters endpoint, for testing
+code built on twitch2go without touching the network.
+
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	channel, err := client.GetChannelByID(twitchtest.SeedChannelID)
+
+The server validates the Client-ID, Accept and Authorization headers the way Twitch does, answers errors with Twitch
+JSON error bodies and pages list endpoints by cursor or offset.
+*/
+package twitchtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+)
+
+// ClientID is the client ID the server accepts unless Server.ClientID is changed.
+const ClientID = "twitchtest-client-id"
+
+const acceptV5 = "application/vnd.twitchtv.v5+json"
+
+type follow struct {
+	userID        string
+	channelID     string
+	createdAt     time.Time
+	notifications bool
+}
+
+type subscription struct {
+	id        string
+	userID    string
+	channelID string
+	createdAt time.Time
+}
+
+// Server is a fake Twitch API server.  Seed it with the Add and Set methods, which are safe to call while the server is
+// handling requests.
+type Server struct {
+	*httptest.Server
+	// ClientID is the client ID every Kraken request must carry.  An empty ClientID accepts any non empty client ID.
+	ClientID string
+
+	mu            sync.Mutex
+	users         map[string]*twitch2go.User
+	channels      map[string]*twitch2go.Channel
+	tokens        map[string]string
+	editors       map[string][]string
+	follows       []follow
+	subscriptions []subscription
+	streams       map[string]*twitch2go.Stream
+	streamKeys    int
+	videos        map[string][]twitch2go.Video
+	chatters      map[string]*twitch2go.Chatters
+	requests      []*http.Request
+}
+
+// NewServer starts and returns an empty fake server.  Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		ClientID: ClientID,
+		users:    map[string]*twitch2go.User{},
+		channels: map[string]*twitch2go.Channel{},
+		tokens:   map[string]string{},
+		editors:  map[string][]string{},
+		streams:  map[string]*twitch2go.Stream{},
+		videos:   map[string][]twitch2go.Video{},
+		chatters: map[string]*twitch2go.Chatters{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a twitch2go Client that sends its API and chatters requests to the server.  Additional options are
+// applied after the ones pointing the Client at the server.
+func (s *Server) Client(opts ...twitch2go.Option) *twitch2go.Client {
+	opts = append([]twitch2go.Option{
+		twitch2go.WithBaseURL(s.URL),
+		twitch2go.WithChattersURL(s.URL + "/group/user/%s/chatters"),
+	}, opts...)
+	client, err := twitch2go.NewClient(s.ClientID, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// Transport returns an http.RoundTripper that redirects every request to the server, whatever its original host.  It
+// is useful for code that builds its own Client or http.Client.
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+	return &rewriteTransport{target: target, base: http.DefaultTransport}
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r2 := r.Clone(r.Context())
+	r2.URL.Scheme = t.target.Scheme
+	r2.URL.Host = t.target.Host
+	r2.Host = ""
+	return t.base.RoundTrip(r2)
+}
+
+// Requests returns the requests the server has received so far.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...)
+}
+
+// AddUser adds a user.  Users are looked up by ID.
+func (s *Server) AddUser(u twitch2go.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[u.ID.String()] = &u
+}
+
+// AddChannel adds a channel.  Channels are looked up by ID and, for the chatters endpoint, by name.
+func (s *Server) AddChannel(ch twitch2go.Channel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channels[ch.ID.String()] = &ch
+}
+
+// AddToken registers an oauth token belonging to the given user.
+func (s *Server) AddToken(token string, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token] = userID
+}
+
+// AddEditor makes the given user an editor of the given channel.
+func (s *Server) AddEditor(channelID string, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.editors[channelID] = append(s.editors[channelID], userID)
+}
+
+// AddFollow makes the given user follow the given channel.
+func (s *Server) AddFollow(userID string, channelID string, createdAt time.Time, notifications bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.follows = append(s.follows, follow{userID: userID, channelID: channelID, createdAt: createdAt, notifications: notifications})
+}
+
+// AddSubscription subscribes the given user to the given channel.
+func (s *Server) AddSubscription(userID string, channelID string, createdAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := fmt.Sprintf("%040x", len(s.subscriptions)+1)
+	s.subscriptions = append(s.subscriptions, subscription{id: id, userID: userID, channelID: channelID, createdAt: createdAt})
+}
+
+// SetStream marks the given channel as live with the given stream.  The stream's Channel is filled in from the seeded
+// channel when left empty.
+func (s *Server) SetStream(channelID string, stream twitch2go.Stream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stream.Channel.ID == "" {
+		if ch, ok := s.channels[channelID]; ok {
+			stream.Channel = *ch
+		}
+	}
+	s.streams[channelID] = &stream
+}
+
+// EndStream marks the given channel as offline.
+func (s *Server) EndStream(channelID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.streams, channelID)
+}
+
+// AddVideo adds a video to the given channel.
+func (s *Server) AddVideo(channelID string, video twitch2go.Video) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.videos[channelID] = append(s.videos[channelID], video)
+}
+
+// SetChatters sets the chatters of the channel with the given name.
+func (s *Server) SetChatters(channelName string, chatters twitch2go.Chatters) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chatters[strings.ToLower(channelName)] = &chatters
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	s.mu.Unlock()
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 4 && parts[0] == "group" && parts[1] == "user" && parts[3] == "chatters" {
+		s.serveChatters(w, r, parts[2])
+		return
+	}
+	if len(parts) < 2 || parts[0] != "kraken" {
+		writeError(w, http.StatusNotFound, "No such endpoint")
+		return
+	}
+	if !s.checkHeaders(w, r) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Method == http.MethodGet {
+		s.route(w, r, parts[1:])
+	} else {
+		s.routeWrite(w, r, parts[1:])
+	}
+}
+
+// checkHeaders validates the headers every Kraken request must carry.
+func (s *Server) checkHeaders(w http.ResponseWriter, r *http.Request) bool {
+	clientID := r.Header.Get("Client-ID")
+	if clientID == "" || (s.ClientID != "" && clientID != s.ClientID) {
+		writeError(w, http.StatusBadRequest, "No client id specified")
+		return false
+	}
+	if r.Header.Get("Accept") != acceptV5 {
+		writeError(w, http.StatusGone, "This API requires the "+acceptV5+" Accept header")
+		return false
+	}
+	return true
+}
+
+// authorize returns the ID of the user owning the request's oauth token.  Must be called with s.mu held.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "OAuth ") {
+		writeError(w, http.StatusUnauthorized, "authentication failed")
+		return "", false
+	}
+	userID, ok := s.tokens[strings.TrimPrefix(auth, "OAuth ")]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid oauth token")
+		return "", false
+	}
+	return userID, true
+}
+
+// route dispatches a Kraken GET request.  parts is the path below /kraken.  Must be called with s.mu held.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 1 && parts[0] == "channel":
+		s.getChannelByOAuth(w, r)
+	case len(parts) == 1 && parts[0] == "user":
+		s.getUserByOAuth(w, r)
+	case len(parts) == 2 && parts[0] == "channels":
+		s.getChannel(w, parts[1])
+	case len(parts) == 3 && parts[0] == "channels" && parts[2] == "editors":
+		s.getChannelEditors(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "channels" && parts[2] == "follows":
+		s.getChannelFollows(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "channels" && parts[2] == "subscriptions":
+		s.getChannelSubscriptions(w, r, parts[1])
+	case len(parts) == 4 && parts[0] == "channels" && parts[2] == "subscriptions":
+		s.getChannelSubscription(w, r, parts[1], parts[3])
+	case len(parts) == 3 && parts[0] == "channels" && parts[2] == "videos":
+		s.getChannelVideos(w, r, parts[1])
+	case len(parts) == 1 && parts[0] == "streams":
+		s.getStreams(w, r)
+	case len(parts) == 2 && parts[0] == "streams" && parts[1] == "followed":
+		s.getFollowedStreams(w, r)
+	case len(parts) == 2 && parts[0] == "streams":
+		s.getStream(w, parts[1])
+	case len(parts) == 1 && parts[0] == "users":
+		s.getUsersByLogin(w, r)
+	case len(parts) == 2 && parts[0] == "users":
+		s.getUser(w, parts[1])
+	case len(parts) == 4 && parts[0] == "users" && parts[2] == "follows" && parts[3] == "channels":
+		s.getUserFollows(w, r, parts[1])
+	case len(parts) == 5 && parts[0] == "users" && parts[2] == "follows" && parts[3] == "channels":
+		s.getUserFollow(w, parts[1], parts[4])
+	case len(parts) == 4 && parts[0] == "users" && parts[2] == "subscriptions":
+		s.getUserSubscription(w, r, parts[1], parts[3])
+	case len(parts) == 2 && parts[0] == "search" && parts[1] == "channels":
+		s.searchChannels(w, r)
+	case len(parts) == 2 && parts[0] == "search" && parts[1] == "users":
+		s.searchUsers(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "No such endpoint")
+	}
+}
+
+func (s *Server) getChannelByOAuth(w http.ResponseWriter, r *http.Request) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	s.getChannel(w, userID)
+}
+
+func (s *Server) getUserByOAuth(w http.ResponseWriter, r *http.Request) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	s.getUser(w, userID)
+}
+
+func (s *Server) getChannel(w http.ResponseWriter, channelID string) {
+	ch, ok := s.channels[channelID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	writeJSON(w, ch)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, userID string) {
+	u, ok := s.users[userID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("User '%s' does not exist", userID))
+		return
+	}
+	writeJSON(w, u)
+}
+
+func (s *Server) getUsersByLogin(w http.ResponseWriter, r *http.Request) {
+	logins := strings.Split(r.URL.Query().Get("login"), ",")
+	if len(logins) > 100 {
+		writeError(w, http.StatusBadRequest, "Too many logins")
+		return
+	}
+	resp := twitch2go.Users{Users: []twitch2go.User{}}
+	for _, login := range logins {
+		for _, u := range s.users {
+			if u.Name == strings.ToLower(login) {
+				resp.Users = append(resp.Users, *u)
+			}
+		}
+	}
+	resp.Total = uint(len(resp.Users))
+	writeJSON(w, resp)
+}
+
+func (s *Server) getChannelEditors(w http.ResponseWriter, r *http.Request, channelID string) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	if userID != channelID {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return
+	}
+	editors := twitch2go.Editors{Users: []twitch2go.User{}}
+	for _, id := range s.editors[channelID] {
+		if u, ok := s.users[id]; ok {
+			editors.Users = append(editors.Users, *u)
+		}
+	}
+	writeJSON(w, editors)
+}
+
+type channelFollow struct {
+	CreatedAt     time.Time      `json:"created_at"`
+	Notifications bool           `json:"notifications"`
+	User          twitch2go.User `json:"user"`
+}
+
+type channelFollows struct {
+	Total   int             `json:"_total"`
+	Cursor  string          `json:"_cursor,omitempty"`
+	Follows []channelFollow `json:"follows"`
+}
+
+func (s *Server) getChannelFollows(w http.ResponseWriter, r *http.Request, channelID string) {
+	if _, ok := s.channels[channelID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	q := r.URL.Query()
+	var follows []follow
+	for _, f := range s.follows {
+		if f.channelID == channelID {
+			follows = append(follows, f)
+		}
+	}
+	sortFollows(follows, q.Get("direction") != "asc")
+	// Cursors are opaque to clients, this server uses the offset of the next page.
+	offset, _ := strconv.Atoi(q.Get("cursor"))
+	start, end := window(len(follows), offset, limitParam(q, 25))
+	resp := channelFollows{Total: len(follows), Follows: []channelFollow{}}
+	for _, f := range follows[start:end] {
+		resp.Follows = append(resp.Follows, channelFollow{CreatedAt: f.createdAt, Notifications: f.notifications, User: s.userOrStub(f.userID)})
+	}
+	if end < len(follows) {
+		resp.Cursor = strconv.Itoa(end)
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) getChannelSubscriptions(w http.ResponseWriter, r *http.Request, channelID string) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	if userID != channelID {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return
+	}
+	q := r.URL.Query()
+	var subs []subscription
+	for _, sub := range s.subscriptions {
+		if sub.channelID == channelID {
+			subs = append(subs, sub)
+		}
+	}
+	sort.SliceStable(subs, func(i, j int) bool {
+		if q.Get("direction") == "desc" {
+			return subs[i].createdAt.After(subs[j].createdAt)
+		}
+		return subs[i].createdAt.Before(subs[j].createdAt)
+	})
+	start, end := window(len(subs), intParam(q, "offset"), limitParam(q, 25))
+	resp := twitch2go.Subscribers{Total: uint(len(subs)), Subscriptions: []twitch2go.Subscription{}}
+	for _, sub := range subs[start:end] {
+		resp.Subscriptions = append(resp.Subscriptions, s.subscriptionJSON(sub))
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) getChannelSubscription(w http.ResponseWriter, r *http.Request, channelID string, subscriberID string) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	if userID != channelID {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return
+	}
+	for _, sub := range s.subscriptions {
+		if sub.channelID == channelID && sub.userID == subscriberID {
+			writeJSON(w, s.subscriptionJSON(sub))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s has no subscriptions to %s", subscriberID, channelID))
+}
+
+func (s *Server) subscriptionJSON(sub subscription) twitch2go.Subscription {
+	return twitch2go.Subscription{ID: sub.id, CreatedAt: sub.createdAt, User: s.userOrStub(sub.userID)}
+}
+
+func (s *Server) getChannelVideos(w http.ResponseWriter, r *http.Request, channelID string) {
+	if _, ok := s.channels[channelID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	q := r.URL.Query()
+	videos := s.videos[channelID]
+	start, end := window(len(videos), intParam(q, "offset"), limitParam(q, 10))
+	resp := twitch2go.Videos{Total: uint(len(videos)), Videos: append([]twitch2go.Video{}, videos[start:end]...)}
+	writeJSON(w, resp)
+}
+
+func (s *Server) getStream(w http.ResponseWriter, channelID string) {
+	if _, ok := s.channels[channelID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	writeJSON(w, twitch2go.StreamResponse{Stream: s.streams[channelID]})
+}
+
+func (s *Server) getStreams(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var channels map[string]bool
+	if q.Get("channel") != "" {
+		channels = map[string]bool{}
+		for _, id := range strings.Split(q.Get("channel"), ",") {
+			channels[id] = true
+		}
+	}
+	streams := []twitch2go.Stream{}
+	for channelID, stream := range s.streams {
+		switch {
+		case channels != nil && !channels[channelID]:
+		case q.Get("game") != "" && q.Get("game") != stream.Game:
+		case q.Get("language") != "" && q.Get("language") != stream.Channel.BroadcasterLanguage:
+		case q.Get("stream_type") == "live" && stream.IsPlaylist:
+		case q.Get("stream_type") == "playlist" && !stream.IsPlaylist:
+		default:
+			streams = append(streams, *stream)
+		}
+	}
+	sort.SliceStable(streams, func(i, j int) bool {
+		if streams[i].Viewers != streams[j].Viewers {
+			return streams[i].Viewers > streams[j].Viewers
+		}
+		return streams[i].Channel.ID < streams[j].Channel.ID
+	})
+	start, end := window(len(streams), intParam(q, "offset"), limitParam(q, 25))
+	writeJSON(w, twitch2go.Streams{Total: uint(len(streams)), Streams: streams[start:end]})
+}
+
+func (s *Server) getFollowedStreams(w http.ResponseWriter, r *http.Request) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	resp := twitch2go.FollowedStream{Streams: []twitch2go.Stream{}}
+	for _, f := range s.follows {
+		if stream, live := s.streams[f.channelID]; f.userID == userID && live {
+			resp.Streams = append(resp.Streams, *stream)
+		}
+	}
+	resp.Total = uint(len(resp.Streams))
+	writeJSON(w, resp)
+}
+
+type userFollow struct {
+	CreatedAt     time.Time         `json:"created_at"`
+	Notifications bool              `json:"notifications"`
+	Channel       twitch2go.Channel `json:"channel"`
+}
+
+type userFollows struct {
+	Total   int          `json:"_total"`
+	Follows []userFollow `json:"follows"`
+}
+
+func (s *Server) getUserFollows(w http.ResponseWriter, r *http.Request, userID string) {
+	if _, ok := s.users[userID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("User '%s' does not exist", userID))
+		return
+	}
+	q := r.URL.Query()
+	var follows []follow
+	for _, f := range s.follows {
+		if f.userID == userID {
+			follows = append(follows, f)
+		}
+	}
+	desc := q.Get("direction") != "asc"
+	if q.Get("sortby") == "login" {
+		sort.SliceStable(follows, func(i, j int) bool {
+			a, b := s.channelOrStub(follows[i].channelID).Name, s.channelOrStub(follows[j].channelID).Name
+			if desc {
+				return a > b
+			}
+			return a < b
+		})
+	} else {
+		sortFollows(follows, desc)
+	}
+	start, end := window(len(follows), intParam(q, "offset"), limitParam(q, 25))
+	resp := userFollows{Total: len(follows), Follows: []userFollow{}}
+	for _, f := range follows[start:end] {
+		resp.Follows = append(resp.Follows, userFollow{CreatedAt: f.createdAt, Notifications: f.notifications, Channel: s.channelOrStub(f.channelID)})
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) getUserFollow(w http.ResponseWriter, userID string, channelID string) {
+	for _, f := range s.follows {
+		if f.userID == userID && f.channelID == channelID {
+			writeJSON(w, userFollow{CreatedAt: f.createdAt, Notifications: f.notifications, Channel: s.channelOrStub(channelID)})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Follow not found")
+}
+
+func (s *Server) getUserSubscription(w http.ResponseWriter, r *http.Request, userID string, channelID string) {
+	tokenUser, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	if tokenUser != userID {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return
+	}
+	for _, sub := range s.subscriptions {
+		if sub.userID == userID && sub.channelID == channelID {
+			writeJSON(w, struct {
+				ID        string            `json:"_id"`
+				CreatedAt time.Time         `json:"created_at"`
+				Channel   twitch2go.Channel `json:"channel"`
+			}{sub.id, sub.createdAt, s.channelOrStub(channelID)})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("%s has no subscriptions to %s", userID, channelID))
+}
+
+func (s *Server) searchChannels(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	resp := twitch2go.Result{Channels: []twitch2go.Channel{}}
+	ids := make([]string, 0, len(s.channels))
+	for id := range s.channels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		ch := s.channels[id]
+		if query != "" && (strings.Contains(strings.ToLower(ch.Name), query) || strings.Contains(strings.ToLower(ch.DisplayName), query)) {
+			resp.Channels = append(resp.Channels, *ch)
+		}
+	}
+	resp.Totals = int64(len(resp.Channels))
+	writeJSON(w, resp)
+}
+
+func (s *Server) searchUsers(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	resp := twitch2go.UserSearchResult{Users: []twitch2go.User{}}
+	ids := make([]string, 0, len(s.users))
+	for id := range s.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		u := s.users[id]
+		if query != "" && (strings.Contains(strings.ToLower(u.Name), query) || strings.Contains(strings.ToLower(u.DisplayName), query)) {
+			resp.Users = append(resp.Users, *u)
+		}
+	}
+	resp.Total = uint(len(resp.Users))
+	writeJSON(w, resp)
+}
+
+func (s *Server) serveChatters(w http.ResponseWriter, r *http.Request, channelName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chatters, ok := s.chatters[strings.ToLower(channelName)]
+	if !ok {
+		chatters = &twitch2go.Chatters{}
+	}
+	c := normalizeChatters(*chatters)
+	count := len(c.Moderators) + len(c.Staff) + len(c.Admins) + len(c.GlobalMods) + len(c.Viewers)
+	writeJSON(w, struct {
+		Links        struct{}           `json:"_links"`
+		ChatterCount int                `json:"chatter_count"`
+		Chatters     twitch2go.Chatters `json:"chatters"`
+	}{ChatterCount: count, Chatters: c})
+}
+
+// userOrStub returns the seeded user with the given ID, or a user carrying only the ID.  Must be called with s.mu held.
+func (s *Server) userOrStub(userID string) twitch2go.User {
+	if u, ok := s.users[userID]; ok {
+		return *u
+	}
+	return twitch2go.User{ID: json.Number(userID)}
+}
+
+// channelOrStub returns the seeded channel with the given ID, or a channel carrying only the ID.  Must be called with
+// s.mu held.
+func (s *Server) channelOrStub(channelID string) twitch2go.Channel {
+	if ch, ok := s.channels[channelID]; ok {
+		return *ch
+	}
+	return twitch2go.Channel{ID: json.Number(channelID)}
+}
+
+func sortFollows(follows []follow, desc bool) {
+	sort.SliceStable(follows, func(i, j int) bool {
+		if desc {
+			return follows[i].createdAt.After(follows[j].createdAt)
+		}
+		return follows[i].createdAt.Before(follows[j].createdAt)
+	})
+}
+
+func normalizeChatters(c twitch2go.Chatters) twitch2go.Chatters {
+	for _, l := range []*[]string{&c.Moderators, &c.Staff, &c.Admins, &c.GlobalMods, &c.Viewers} {
+		if *l == nil {
+			*l = []string{}
+		}
+	}
+	return c
+}
+
+// window returns the bounds of the page starting at offset in a list of n items.
+func window(n int, offset int, limit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end
+}
+
+func intParam(q url.Values, key string) int {
+	i, _ := strconv.Atoi(q.Get(key))
+	return i
+}
+
+// limitParam returns the limit query parameter clamped the way Twitch does.
+func limitParam(q url.Values, def int) int {
+	limit := intParam(q, "limit")
+	if limit <= 0 {
+		return def
+	} else if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(twitch2go.ResponseError{
+		Error:   http.StatusText(status),
+		Message: message,
+		Status:  json.Number(strconv.Itoa(status)),
+	})
+}
@@ -0,0 +1,291 @@
+package twitchtest_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+	"github.com/kenXengineering/twitch2go/twitchtest"
+)
+
+func TestSeededChannel(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	channel, err := client.GetChannelByID(twitchtest.SeedChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel.Name != twitchtest.SeedChannelName {
+		t.Errorf("Expected channel %q.  Got %q.", twitchtest.SeedChannelName, channel.Name)
+	}
+	if _, err := client.GetChannelByID("404"); !twitch2go.IsNotFound(err) {
+		t.Errorf("Expected a not found error.  Got %v.", err)
+	}
+}
+
+func TestHeaderValidation(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client, err := twitch2go.NewClient("wrong-client-id", twitch2go.WithHTTPClient(&http.Client{Transport: srv.Transport()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetChannelByID(twitchtest.SeedChannelID)
+	if apiErr, ok := twitch2go.AsError(err); !ok || apiErr.Status != http.StatusBadRequest {
+		t.Errorf("Expected a bad request error for an unknown client ID.  Got %v.", err)
+	}
+	if _, err := srv.Client().GetChannelByOAuth("not-a-token"); !twitch2go.IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error.  Got %v.", err)
+	}
+	channel, err := srv.Client().GetChannelByOAuth(twitchtest.SeedOAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel.ID.String() != twitchtest.SeedChannelID {
+		t.Errorf("Expected the token owner's channel.  Got %q.", channel.ID)
+	}
+}
+
+func TestFollowsPagination(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	first, err := client.GetChannelFollows(twitchtest.SeedChannelID, "", 100, twitch2go.DESC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Total != twitchtest.SeedFollowerCount || len(first.Follows) != 100 || first.Cursor == "" {
+		t.Fatalf("Expected a full first page with a cursor.  Got %d follows, total %d, cursor %q.", len(first.Follows), first.Total, first.Cursor)
+	}
+	second, err := client.GetChannelFollows(twitchtest.SeedChannelID, first.Cursor, 100, twitch2go.DESC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Follows) != twitchtest.SeedFollowerCount-100 || second.Cursor != "" {
+		t.Errorf("Expected the last page without a cursor.  Got %d follows, cursor %q.", len(second.Follows), second.Cursor)
+	}
+	if first.Follows[0].User.Name != "follower0" {
+		t.Errorf("Expected newest follower first.  Got %q.", first.Follows[0].User.Name)
+	}
+}
+
+func TestSubscribers(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	subs, err := client.GetChannelSubscribers(twitchtest.SeedChannelID, twitchtest.SeedOAuth, 25, 25, twitch2go.ASC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs.Total != twitchtest.SeedSubscriberCount || len(subs.Subscriptions) != twitchtest.SeedSubscriberCount-25 {
+		t.Errorf("Expected the second page of subscribers.  Got %d of %d.", len(subs.Subscriptions), subs.Total)
+	}
+	if _, err := client.GetChannelSubscribers(twitchtest.SeedLiveChannelID, twitchtest.SeedOAuth, 25, 0, twitch2go.ASC); err == nil {
+		t.Error("Expected listing another channel's subscribers to fail.")
+	}
+	subscribed, err := client.CheckUserSubscriptionByChannel(twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID, twitchtest.SeedOAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscribed {
+		t.Error("Expected the broadcaster not to be subscribed.")
+	}
+}
+
+func TestStreamsAndChatters(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	stream, err := client.GetStreamByChannel(twitchtest.SeedLiveChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stream.Channel.Name != twitchtest.SeedLiveChannelName {
+		t.Errorf("Expected the live stream of %q.  Got %q.", twitchtest.SeedLiveChannelName, stream.Channel.Name)
+	}
+	if _, err := client.GetStreamByChannel(twitchtest.SeedChannelID); !twitch2go.IsStreamOffline(err) {
+		t.Errorf("Expected %s to be offline.  Got %v.", twitchtest.SeedChannelName, err)
+	}
+	followed, err := client.GetFollowedStreams(twitchtest.SeedOAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if followed.Total != 1 {
+		t.Errorf("Expected 1 followed live stream.  Got %d.", followed.Total)
+	}
+	chatters, err := client.GetChatters(twitchtest.SeedChannelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chatters.ChatterCount != 5 || len(chatters.Chatters.Moderators) != 2 {
+		t.Errorf("Expected 5 chatters with 2 moderators.  Got %#v.", chatters)
+	}
+}
+
+func TestIteratorsAgainstServer(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	it := client.IterateChannelVideos(twitchtest.SeedChannelID, 5, "", "", twitch2go.Time)
+	n := 0
+	for it.Next(context.Background()) {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != twitchtest.SeedVideoCount {
+		t.Errorf("Expected %d videos.  Got %d.", twitchtest.SeedVideoCount, n)
+	}
+	following, err := client.CheckUserFollowsChannel(twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !following {
+		t.Error("Expected the broadcaster to follow the live channel.")
+	}
+}
+
+func TestChannelWrites(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	channel, err := client.UpdateChannel(twitchtest.SeedChannelID, twitch2go.ChannelUpdate{Status: twitch2go.String("New title")}, twitchtest.SeedOAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel.Status != "New title" || channel.Game != "Grand Theft Auto V" {
+		t.Errorf("Expected only the title to change.  Got %q / %q.", channel.Status, channel.Game)
+	}
+	channel, err = client.GetChannelByID(twitchtest.SeedChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channel.Status != "New title" {
+		t.Errorf("Expected the update to persist.  Got %q.", channel.Status)
+	}
+	if _, err := client.UpdateChannel(twitchtest.SeedLiveChannelID, twitch2go.ChannelUpdate{Status: twitch2go.String("x")}, twitchtest.SeedOAuth); err == nil {
+		t.Error("Expected updating a channel the token cannot edit to fail.")
+	}
+	first, err := client.ResetStreamKey(twitchtest.SeedChannelID, twitchtest.SeedOAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := client.ResetStreamKey(twitchtest.SeedChannelID, twitchtest.SeedOAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.StreamKey == "" || first.StreamKey == second.StreamKey {
+		t.Errorf("Expected a new stream key every reset.  Got %q and %q.", first.StreamKey, second.StreamKey)
+	}
+	if _, err := client.StartCommercial(twitchtest.SeedChannelID, 30, twitchtest.SeedOAuth); err == nil {
+		t.Error("Expected a commercial on an offline channel to fail.")
+	}
+}
+
+func TestFollowAndUnfollow(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	follow, err := client.FollowChannel(twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID, true, twitchtest.SeedOAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !follow.Notifications || follow.Channel.Name != twitchtest.SeedLiveChannelName {
+		t.Errorf("Unexpected follow %#v", *follow)
+	}
+	following, err := client.CheckUserFollowsChannel(twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !following {
+		t.Error("Expected the follow to persist.")
+	}
+	if err := client.UnfollowChannel(twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID, twitchtest.SeedOAuth); err != nil {
+		t.Fatal(err)
+	}
+	following, err = client.CheckUserFollowsChannel(twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if following {
+		t.Error("Expected the follow to be removed.")
+	}
+	err = client.UnfollowChannel(twitchtest.SeedLiveChannelID, twitchtest.SeedChannelID, twitchtest.SeedOAuth)
+	if !twitch2go.IsForbidden(err) {
+		t.Errorf("Expected unfollowing for another user to be forbidden.  Got %v.", err)
+	}
+}
+
+func TestStreamWatcher(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	watcher := twitch2go.NewStreamWatcher(srv.Client(), twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID)
+	watcher.Interval = 5 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := watcher.Watch(ctx)
+	event := <-events
+	if event.Type != twitch2go.WentLive || event.ChannelID != twitchtest.SeedLiveChannelID {
+		t.Fatalf("Expected %s for %s.  Got %s for %s.", twitch2go.WentLive, twitchtest.SeedLiveChannelID, event.Type, event.ChannelID)
+	}
+	srv.SetStream(twitchtest.SeedChannelID, twitch2go.Stream{ID: "1234", Game: "Overwatch", Viewers: 3})
+	event = <-events
+	if event.Type != twitch2go.WentLive || event.ChannelID != twitchtest.SeedChannelID {
+		t.Fatalf("Expected %s for %s.  Got %s for %s.", twitch2go.WentLive, twitchtest.SeedChannelID, event.Type, event.ChannelID)
+	}
+	srv.EndStream(twitchtest.SeedLiveChannelID)
+	event = <-events
+	if event.Type != twitch2go.WentOffline || event.ChannelID != twitchtest.SeedLiveChannelID {
+		t.Fatalf("Expected %s for %s.  Got %s for %s.", twitch2go.WentOffline, twitchtest.SeedLiveChannelID, event.Type, event.ChannelID)
+	}
+	cancel()
+	for range events {
+	}
+}
+
+func TestGetStreams(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	client := srv.Client()
+	streams, err := client.GetStreams([]string{twitchtest.SeedChannelID, twitchtest.SeedLiveChannelID}, twitch2go.StreamsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 1 || streams[twitchtest.SeedLiveChannelID].Channel.Name != twitchtest.SeedLiveChannelName {
+		t.Errorf("Expected only the stream of %s.  Got %#v.", twitchtest.SeedLiveChannelName, streams)
+	}
+	streams, err = client.GetStreams([]string{twitchtest.SeedLiveChannelID}, twitch2go.StreamsOptions{Game: "Not Played"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(streams) != 0 {
+		t.Errorf("Expected the game filter to exclude the stream.  Got %#v.", streams)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	srv := twitchtest.NewSeededServer()
+	defer srv.Close()
+	resolver := twitch2go.NewResolver(srv.Client(), 0, 0)
+	ctx := context.Background()
+	ids, err := resolver.IDs(ctx, []string{twitchtest.SeedChannelName, "CohhCarnage", "nobody"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{twitchtest.SeedChannelName: twitchtest.SeedChannelID, twitchtest.SeedLiveChannelName: twitchtest.SeedLiveChannelID}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %#v.  Got %#v.", expected, ids)
+	}
+	login, err := resolver.Login(ctx, twitchtest.SeedLiveChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if login != twitchtest.SeedLiveChannelName {
+		t.Errorf("Expected %#v.  Got %#v.", twitchtest.SeedLiveChannelName, login)
+	}
+}
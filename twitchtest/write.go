@@ -0,0 +1,190 @@
+package twitchtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	twitch2go "github.com/kenXengineering/twitch2go"
+)
+
+// routeWrite dispatches a Kraken PUT, POST or DELETE request.  parts is the path below /kraken.  Must be called with
+// s.mu held.
+func (s *Server) routeWrite(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case r.Method == http.MethodPut && len(parts) == 2 && parts[0] == "channels":
+		s.updateChannel(w, r, parts[1])
+	case r.Method == http.MethodDelete && len(parts) == 3 && parts[0] == "channels" && parts[2] == "stream_key":
+		s.resetStreamKey(w, r, parts[1])
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[0] == "channels" && parts[2] == "commercial":
+		s.startCommercial(w, r, parts[1])
+	case r.Method == http.MethodPut && len(parts) == 5 && parts[0] == "users" && parts[2] == "follows" && parts[3] == "channels":
+		s.followChannel(w, r, parts[1], parts[4])
+	case r.Method == http.MethodDelete && len(parts) == 5 && parts[0] == "users" && parts[2] == "follows" && parts[3] == "channels":
+		s.unfollowChannel(w, r, parts[1], parts[4])
+	default:
+		writeError(w, http.StatusNotFound, "No such endpoint")
+	}
+}
+
+// canEdit reports whether the given user may change the given channel: its owner or one of its editors.  Must be
+// called with s.mu held.
+func (s *Server) canEdit(userID string, channelID string) bool {
+	if userID == channelID {
+		return true
+	}
+	for _, id := range s.editors[channelID] {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeBody decodes the JSON body of r into v, answering 400 Bad Request when it is malformed.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func (s *Server) updateChannel(w http.ResponseWriter, r *http.Request, channelID string) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	ch, ok := s.channels[channelID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	if !s.canEdit(userID, channelID) {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return
+	}
+	var body struct {
+		Channel twitch2go.ChannelUpdate `json:"channel"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	update := body.Channel
+	if update.Delay != nil && !ch.Partner {
+		writeError(w, http.StatusUnprocessableEntity, "Only partnered channels can set a delay")
+		return
+	}
+	if update.Status != nil {
+		ch.Status = *update.Status
+	}
+	if update.Game != nil {
+		ch.Game = *update.Game
+	}
+	ch.UpdatedAt = time.Now().UTC()
+	writeJSON(w, ch)
+}
+
+func (s *Server) resetStreamKey(w http.ResponseWriter, r *http.Request, channelID string) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	ch, ok := s.channels[channelID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	if userID != channelID {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return
+	}
+	s.streamKeys++
+	reset := *ch
+	reset.StreamKey = fmt.Sprintf("live_%s_twitchtest%d", channelID, s.streamKeys)
+	writeJSON(w, reset)
+}
+
+func (s *Server) startCommercial(w http.ResponseWriter, r *http.Request, channelID string) {
+	userID, ok := s.authorize(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := s.channels[channelID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	if !s.canEdit(userID, channelID) {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return
+	}
+	var body struct {
+		Length uint `json:"length"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	valid := false
+	for _, l := range twitch2go.CommercialLengths {
+		valid = valid || l == body.Length
+	}
+	if !valid {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Invalid commercial length %d", body.Length))
+		return
+	}
+	if _, live := s.streams[channelID]; !live {
+		writeError(w, http.StatusUnprocessableEntity, "Commercials can only be run while live")
+		return
+	}
+	writeJSON(w, twitch2go.Commercial{Length: body.Length, RetrySeconds: 480})
+}
+
+// authorizeUser authorizes r and checks that its token belongs to userID.  Must be called with s.mu held.
+func (s *Server) authorizeUser(w http.ResponseWriter, r *http.Request, userID string) bool {
+	tokenUser, ok := s.authorize(w, r)
+	if !ok {
+		return false
+	}
+	if tokenUser != userID {
+		writeError(w, http.StatusForbidden, "Insufficient authorization")
+		return false
+	}
+	return true
+}
+
+func (s *Server) followChannel(w http.ResponseWriter, r *http.Request, userID string, channelID string) {
+	if !s.authorizeUser(w, r, userID) {
+		return
+	}
+	if _, ok := s.channels[channelID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Channel '%s' does not exist", channelID))
+		return
+	}
+	notifications := r.URL.Query().Get("notifications") == "true"
+	for i, f := range s.follows {
+		if f.userID == userID && f.channelID == channelID {
+			// Following again only updates the notification setting.
+			s.follows[i].notifications = notifications
+			writeJSON(w, userFollow{CreatedAt: f.createdAt, Notifications: notifications, Channel: s.channelOrStub(channelID)})
+			return
+		}
+	}
+	f := follow{userID: userID, channelID: channelID, createdAt: time.Now().UTC(), notifications: notifications}
+	s.follows = append(s.follows, f)
+	writeJSON(w, userFollow{CreatedAt: f.createdAt, Notifications: notifications, Channel: s.channelOrStub(channelID)})
+}
+
+func (s *Server) unfollowChannel(w http.ResponseWriter, r *http.Request, userID string, channelID string) {
+	if !s.authorizeUser(w, r, userID) {
+		return
+	}
+	for i, f := range s.follows {
+		if f.userID == userID && f.channelID == channelID {
+			s.follows = append(s.follows[:i], s.follows[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Follow not found")
+}
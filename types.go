@@ -2,6 +2,8 @@ package twitch2go
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -19,6 +21,15 @@ const (
 	Time          VideoSort = "time"
 )
 
+// StreamType filters streams by whether they are live broadcasts or replayed playlists.
+type StreamType string
+
+const (
+	AllStreams  StreamType = "all"
+	LiveStreams StreamType = "live"
+	Playlists   StreamType = "playlist"
+)
+
 // Channel Twitch Channel Data
 type Channel struct {
 	Mature                       bool        `json:"mature"`
@@ -39,15 +50,106 @@ type Channel struct {
 	URL                          string      `json:"url"`
 	Views                        uint        `json:"views"`
 	Followers                    uint        `json:"followers"`
+	// Email and StreamKey are only returned for the channel of the authenticated user.
+	Email     string `json:"email,omitempty"`
+	StreamKey string `json:"stream_key,omitempty"`
 }
 
+// Team Twitch Team Data.  Channels holds the member channels and is only filled in by GetTeam.
+type Team struct {
+	ID          json.Number `json:"_id,number"`
+	Name        string      `json:"name"`
+	DisplayName string      `json:"display_name"`
+	Info        string      `json:"info"`
+	Background  string      `json:"background"`
+	Banner      string      `json:"banner"`
+	Logo        string      `json:"logo"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	Channels    []Channel   `json:"users,omitempty"`
+}
+
+type Teams struct {
+	Teams []Team `json:"teams"`
+}
+
+// Post Twitch Channel Feed Post Data.  Reactions are keyed by emote ID, `endorse` being the default reaction.
+// Permissions are those of the user whose oauth token fetched the post.
 type Post struct {
-	ID        json.Number `json:"id,number"`
-	CreatedAt time.Time   `json:"created_at"`
-	Deleted   bool        `json:"deleted"`
-	Emotes    []string    `json:"emotes"`
-	Body      string      `json:"body"`
-	User      User        `json:"user"`
+	ID          json.Number         `json:"id,number"`
+	CreatedAt   time.Time           `json:"created_at"`
+	Deleted     bool                `json:"deleted"`
+	Emotes      []PostEmote         `json:"emotes"`
+	Body        string              `json:"body"`
+	User        User                `json:"user"`
+	Embeds      []PostEmbed         `json:"embeds"`
+	Reactions   map[string]Reaction `json:"reactions"`
+	Permissions PostPermissions     `json:"permissions"`
+	Comments    Comments            `json:"comments"`
+}
+
+// PostEmote is an emote used in a post or comment body, from byte Start to byte End.
+type PostEmote struct {
+	ID    int `json:"id"`
+	Set   int `json:"set"`
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// PostEmbed is a link in a post that Twitch shows as a preview, like a video or a tweet.
+type PostEmbed struct {
+	Type         string `json:"type"`
+	RequestURL   string `json:"request_url"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	HTML         string `json:"html"`
+}
+
+type Reaction struct {
+	Count   uint          `json:"count"`
+	Emote   string        `json:"emote"`
+	UserIDs []json.Number `json:"user_ids"`
+}
+
+type PostPermissions struct {
+	CanReply    bool `json:"can_reply"`
+	CanModerate bool `json:"can_moderate"`
+	CanDelete   bool `json:"can_delete"`
+}
+
+type Posts struct {
+	Cursor   string `json:"_cursor"`
+	Topic    string `json:"_topic"`
+	Disabled bool   `json:"_disabled"`
+	Posts    []Post `json:"posts"`
+}
+
+type Comment struct {
+	ID          json.Number         `json:"id,number"`
+	CreatedAt   time.Time           `json:"created_at"`
+	Deleted     bool                `json:"deleted"`
+	Emotes      []PostEmote         `json:"emotes"`
+	Body        string              `json:"body"`
+	User        User                `json:"user"`
+	Reactions   map[string]Reaction `json:"reactions"`
+	Permissions PostPermissions     `json:"permissions"`
+}
+
+type Comments struct {
+	Total    uint      `json:"_total"`
+	Cursor   string    `json:"_cursor"`
+	Comments []Comment `json:"comments"`
+}
+
+// PostReaction is a reaction added to a post or comment.
+type PostReaction struct {
+	ID        string    `json:"id"`
+	EmoteID   string    `json:"emote_id"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // Follower data for twitch channel
@@ -66,9 +168,12 @@ type Followers struct {
 }
 
 type Subscription struct {
-	ID        json.Number `json:"_id,number"`
-	CreatedAt time.Time   `json:"created_at"`
-	User      User        `json:"user"`
+	ID          string    `json:"_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	SubPlan     string    `json:"sub_plan"`
+	SubPlanName string    `json:"sub_plan_name"`
+	IsGift      bool      `json:"is_gift"`
+	User        User      `json:"user"`
 }
 
 type Subscribers struct {
@@ -82,6 +187,11 @@ type UserSearchResult struct {
 	Users []User `json:"users"`
 }
 
+type Users struct {
+	Total uint   `json:"_total"`
+	Users []User `json:"users"`
+}
+
 // User Twitch User Data
 type User struct {
 	Type             string        `json:"type"`
@@ -99,6 +209,17 @@ type User struct {
 	Notifications    Notifications `json:"notifications"`
 }
 
+type Block struct {
+	ID        json.Number `json:"_id,number"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	User      User        `json:"user"`
+}
+
+type Blocks struct {
+	Total  uint    `json:"_total"`
+	Blocks []Block `json:"blocks"`
+}
+
 type Notifications struct {
 	Email bool `json:"email"`
 	Push  bool `json:"push"`
@@ -109,7 +230,10 @@ type Editors struct {
 }
 
 type Videos struct {
-	Total  uint    `json:"_total"`
+	// Total is the number of videos matching, across all pages.  HelixClient leaves it at 0, Helix does not report it.
+	Total uint `json:"_total"`
+	// Cursor is only set by HelixClient, Kraken pages videos by offset.
+	Cursor string  `json:"_cursor,omitempty"`
 	Videos []Video `json:"videos"`
 }
 
@@ -165,6 +289,38 @@ type Preview struct {
 	Template string `json:"template"`
 }
 
+// URL returns the image in the given size, filled in from Template.
+func (p Preview) URL(width int, height int) string {
+	return strings.NewReplacer("{width}", strconv.Itoa(width), "{height}", strconv.Itoa(height)).Replace(p.Template)
+}
+
+// Game Twitch Game Data.  Box is the box art and Logo the game logo, both in several sizes.
+type Game struct {
+	ID            json.Number `json:"_id,number"`
+	Name          string      `json:"name"`
+	LocalizedName string      `json:"localized_name"`
+	Locale        string      `json:"locale"`
+	GiantbombID   json.Number `json:"giantbomb_id,number"`
+	Popularity    uint        `json:"popularity"`
+	Box           Preview     `json:"box"`
+	Logo          Preview     `json:"logo"`
+}
+
+type TopGame struct {
+	Channels uint `json:"channels"`
+	Viewers  uint `json:"viewers"`
+	Game     Game `json:"game"`
+}
+
+type TopGames struct {
+	Total uint      `json:"_total"`
+	Top   []TopGame `json:"top"`
+}
+
+type GameSearchResult struct {
+	Games []Game `json:"games"`
+}
+
 type Fps struct {
 	Chunked float64 `json:"chunked"`
 	High    float64 `json:"high"`
@@ -187,8 +343,37 @@ type Stream struct {
 	Channel     Channel     `json:"channel"`
 }
 
+// StreamResponse is the response of the stream by channel endpoint.  Stream is nil when the channel is offline.
 type StreamResponse struct {
-	Stream Stream `json:"stream"`
+	Stream *Stream `json:"stream"`
+}
+
+type Streams struct {
+	// Total is the number of streams matching, across all pages.  HelixClient leaves it at 0, Helix does not report it.
+	Total uint `json:"_total"`
+	// Cursor is only set by HelixClient.
+	Cursor  string   `json:"_cursor,omitempty"`
+	Streams []Stream `json:"streams"`
+}
+
+// FeaturedStream is a stream promoted on the Twitch front page.  Text is HTML.
+type FeaturedStream struct {
+	Title     string `json:"title"`
+	Text      string `json:"text"`
+	Image     string `json:"image"`
+	Priority  int    `json:"priority"`
+	Scheduled bool   `json:"scheduled"`
+	Sponsored bool   `json:"sponsored"`
+	Stream    Stream `json:"stream"`
+}
+
+type FeaturedStreams struct {
+	Featured []FeaturedStream `json:"featured"`
+}
+
+type StreamsSummary struct {
+	Channels uint `json:"channels"`
+	Viewers  uint `json:"viewers"`
 }
 
 type FollowedStream struct {
@@ -196,6 +381,52 @@ type FollowedStream struct {
 	Streams []Stream `json:"streams"`
 }
 
+// Clip Twitch Clip Data.  Curator is the user who made the clip and VOD the video it was cut from, if it still exists.
+type Clip struct {
+	Slug        string         `json:"slug"`
+	TrackingID  string         `json:"tracking_id"`
+	URL         string         `json:"url"`
+	EmbedURL    string         `json:"embed_url"`
+	EmbedHTML   string         `json:"embed_html"`
+	Broadcaster ClipUser       `json:"broadcaster"`
+	Curator     ClipUser       `json:"curator"`
+	VOD         *ClipVOD       `json:"vod"`
+	BroadcastID string         `json:"broadcast_id"`
+	Game        string         `json:"game"`
+	Language    string         `json:"language"`
+	Title       string         `json:"title"`
+	Views       uint           `json:"views"`
+	Duration    float64        `json:"duration"`
+	CreatedAt   time.Time      `json:"created_at"`
+	Thumbnails  ClipThumbnails `json:"thumbnails"`
+}
+
+type ClipUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	ChannelURL  string `json:"channel_url"`
+	Logo        string `json:"logo"`
+}
+
+type ClipVOD struct {
+	ID              string `json:"id"`
+	URL             string `json:"url"`
+	Offset          uint   `json:"offset"`
+	PreviewImageURL string `json:"preview_image_url"`
+}
+
+type ClipThumbnails struct {
+	Medium string `json:"medium"`
+	Small  string `json:"small"`
+	Tiny   string `json:"tiny"`
+}
+
+type Clips struct {
+	Clips  []Clip `json:"clips"`
+	Cursor string `json:"_cursor"`
+}
+
 type ResponseError struct {
 	Error   string      `json:"error"`
 	Message string      `json:"message"`
@@ -214,3 +445,69 @@ type Chatters struct {
 	GlobalMods []string `json:"global_mods"`
 	Viewers    []string `json:"viewers"`
 }
+
+// EventSubTransport is where Twitch delivers the notifications of an EventSub subscription.  Secret is only sent when
+// creating a subscription.
+type EventSubTransport struct {
+	Method   string `json:"method"`
+	Callback string `json:"callback"`
+	Secret   string `json:"secret,omitempty"`
+}
+
+// EventSubSubscription is a subscription to an EventSub event type.  Condition holds the type specific filter, like
+// `broadcaster_user_id`.
+type EventSubSubscription struct {
+	ID        string            `json:"id"`
+	Status    string            `json:"status"`
+	Type      string            `json:"type"`
+	Version   string            `json:"version"`
+	Condition map[string]string `json:"condition"`
+	Transport EventSubTransport `json:"transport"`
+	CreatedAt time.Time         `json:"created_at"`
+	Cost      int               `json:"cost"`
+}
+
+// EventSubSubscriptions is a page of the subscriptions returned by HelixClient.GetEventSubSubscriptions.  Pass Cursor
+// as EventSubSubscriptionsOptions.After to get the next page; it is empty on the last one.  TotalCost is the sum of the
+// Cost of the client's subscriptions, which Twitch refuses to let grow past MaxTotalCost.
+type EventSubSubscriptions struct {
+	Total         uint                   `json:"total"`
+	TotalCost     int                    `json:"total_cost"`
+	MaxTotalCost  int                    `json:"max_total_cost"`
+	Cursor        string                 `json:"cursor"`
+	Subscriptions []EventSubSubscription `json:"subscriptions"`
+}
+
+// StreamOnlineEvent is the event of a `stream.online` subscription.  Type is `live`, `playlist`, `watch_party`,
+// `premiere` or `rerun`.
+type StreamOnlineEvent struct {
+	ID                   string    `json:"id"`
+	BroadcasterUserID    string    `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name"`
+	Type                 string    `json:"type"`
+	StartedAt            time.Time `json:"started_at"`
+}
+
+// ChannelFollowEvent is the event of a `channel.follow` subscription.
+type ChannelFollowEvent struct {
+	UserID               string    `json:"user_id"`
+	UserLogin            string    `json:"user_login"`
+	UserName             string    `json:"user_name"`
+	BroadcasterUserID    string    `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string    `json:"broadcaster_user_login"`
+	BroadcasterUserName  string    `json:"broadcaster_user_name"`
+	FollowedAt           time.Time `json:"followed_at"`
+}
+
+// ChannelSubscribeEvent is the event of a `channel.subscribe` subscription.  Tier is `1000`, `2000` or `3000`.
+type ChannelSubscribeEvent struct {
+	UserID               string `json:"user_id"`
+	UserLogin            string `json:"user_login"`
+	UserName             string `json:"user_name"`
+	BroadcasterUserID    string `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string `json:"broadcaster_user_login"`
+	BroadcasterUserName  string `json:"broadcaster_user_name"`
+	Tier                 string `json:"tier"`
+	IsGift               bool   `json:"is_gift"`
+}
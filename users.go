@@ -1,8 +1,10 @@
 package twitch2go
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 )
@@ -11,9 +13,16 @@ import (
 GetUserByOauth returns a user from the given oauth token.
 */
 func (c *Client) GetUserByOAuth(oauth string) (*User, error) {
+	return c.GetUserByOAuthContext(context.Background(), oauth)
+}
+
+// GetUserByOAuthContext is like GetUserByOAuth but uses the given context for the request.
+func (c *Client) GetUserByOAuthContext(ctx context.Context, oauth string) (*User, error) {
 	url := "/user"
 	opts := &doOptions{
-		oauth: oauth,
+		oauth:   oauth,
+		scope:   "user_read",
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, opts)
@@ -31,9 +40,14 @@ func (c *Client) GetUserByOAuth(oauth string) (*User, error) {
 
 // GetUserByID will return ther user for the given ID.
 func (c *Client) GetUserByID(userID string) (*User, error) {
+	return c.GetUserByIDContext(context.Background(), userID)
+}
+
+// GetUserByIDContext is like GetUserByID but uses the given context for the request.
+func (c *Client) GetUserByIDContext(ctx context.Context, userID string) (*User, error) {
 	url := "/users/" + userID
 	// Do the request
-	resp, err := c.do("GET", url, &doOptions{})
+	resp, err := c.do("GET", url, &doOptions{context: ctx})
 	if err != nil {
 		return nil, errors.Annotate(err, "GetUserByID")
 	}
@@ -46,6 +60,45 @@ func (c *Client) GetUserByID(userID string) (*User, error) {
 	return user, nil
 }
 
+// maxLoginsPerRequest is the number of logins the users endpoint accepts in one request.
+const maxLoginsPerRequest = 100
+
+// GetUsersByLogin returns the users with the given login names.  Logins that do not exist are left out.  Any number of
+// logins can be given, they are requested 100 at a time.
+func (c *Client) GetUsersByLogin(logins []string) ([]User, error) {
+	return c.GetUsersByLoginContext(context.Background(), logins)
+}
+
+// GetUsersByLoginContext is like GetUsersByLogin but uses the given context for the requests.
+func (c *Client) GetUsersByLoginContext(ctx context.Context, logins []string) ([]User, error) {
+	users := []User{}
+	for start := 0; start < len(logins); start += maxLoginsPerRequest {
+		end := start + maxLoginsPerRequest
+		if end > len(logins) {
+			end = len(logins)
+		}
+		opts := &doOptions{
+			params: map[string]string{
+				"login": strings.Join(logins[start:end], ","),
+			},
+			context: ctx,
+		}
+		// Do the request
+		resp, err := c.do("GET", "/users", opts)
+		if err != nil {
+			return nil, errors.Annotate(err, "GetUsersByLogin")
+		}
+		page := &Users{}
+		err = json.NewDecoder(resp.Body).Decode(page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Annotate(err, "Error decoding JSON")
+		}
+		users = append(users, page.Users...)
+	}
+	return users, nil
+}
+
 /*
 GetUserFollows returns a list of channles the given user follows.
 
@@ -66,6 +119,12 @@ The function takes in five parameters:
 		The sort of the returned list.  Values are `CreatedAt`, `LastBroadcast`, and `Login`  Default is `CreatedAt`.
 */
 func (c *Client) GetUserFollows(userID string, limit int, offset int, direction Direction, sortBy SortBy) (*Followers, error) {
+	return c.GetUserFollowsContext(context.Background(), userID, limit, offset, direction, sortBy)
+}
+
+// GetUserFollowsContext is like GetUserFollows but uses the given context for the request.
+func (c *Client) GetUserFollowsContext(ctx context.Context, userID string, limit int, offset int, direction Direction, sortBy SortBy) (*Followers, error) {
+	limit = pageLimit(limit, 25)
 	url := "/users/" + userID + "/follows/channels"
 	opts := &doOptions{
 		params: map[string]string{
@@ -74,6 +133,7 @@ func (c *Client) GetUserFollows(userID string, limit int, offset int, direction
 			"direction": string(direction),
 			"sortby":    string(sortBy),
 		},
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, opts)
@@ -89,39 +149,205 @@ func (c *Client) GetUserFollows(userID string, limit int, offset int, direction
 	return follows, nil
 }
 
-// CheckUserSubscriptionByChannel will return a user object if the given user is subscribed to the given channel.  If no user and an error is returned, then either the user is not subscribed to the channel, or the channel does not have a subscription program.
-func (c *Client) CheckUserSubscriptionByChannel(userID string, channelID string, oauth string) (*User, error) {
+// CheckUserSubscriptionByChannel reports whether the given user is subscribed to the given channel.  If the user is not
+// subscribed it returns false and a nil error.  An error is still returned if the channel does not have a subscription
+// program.
+func (c *Client) CheckUserSubscriptionByChannel(userID string, channelID string, oauth string) (bool, error) {
+	return c.CheckUserSubscriptionByChannelContext(context.Background(), userID, channelID, oauth)
+}
+
+// CheckUserSubscriptionByChannelContext is like CheckUserSubscriptionByChannel but uses the given context for the request.
+func (c *Client) CheckUserSubscriptionByChannelContext(ctx context.Context, userID string, channelID string, oauth string) (bool, error) {
 	url := "/users/" + userID + "/subscriptions/" + channelID
 	opts := &doOptions{
-		oauth: oauth,
+		oauth:   oauth,
+		scope:   "user_subscriptions",
+		context: ctx,
 	}
 	// Do the request
 	resp, err := c.do("GET", url, opts)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Annotate(err, "CheckUserSubscriptionByChannel")
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+// CheckUserFollowsChannel reports whether the given user is following the given channel.  If the user is not following
+// the channel it returns false and a nil error.
+func (c *Client) CheckUserFollowsChannel(userID string, channelID string) (bool, error) {
+	return c.CheckUserFollowsChannelContext(context.Background(), userID, channelID)
+}
+
+// CheckUserFollowsChannelContext is like CheckUserFollowsChannel but uses the given context for the request.
+func (c *Client) CheckUserFollowsChannelContext(ctx context.Context, userID string, channelID string) (bool, error) {
+	url := "/users/" + userID + "/follows/channels/" + channelID
+	// Do the request
+	resp, err := c.do("GET", url, &doOptions{context: ctx})
+	if IsNotFound(err) {
+		return false, nil
+	}
 	if err != nil {
-		return nil, errors.Annotate(err, "CheckUserSubscriptionByChannel")
+		return false, errors.Annotate(err, "CheckUserFollowsChannel")
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+// FollowChannel makes the given user follow the given channel and returns the new follow.  If notifications is true
+// the user gets notified when the channel goes live.  Requires an oauth token from the user with the
+// `user_follows_edit` scope.
+func (c *Client) FollowChannel(userID string, channelID string, notifications bool, oauth string) (*Follow, error) {
+	return c.FollowChannelContext(context.Background(), userID, channelID, notifications, oauth)
+}
+
+// FollowChannelContext is like FollowChannel but uses the given context for the request.
+func (c *Client) FollowChannelContext(ctx context.Context, userID string, channelID string, notifications bool, oauth string) (*Follow, error) {
+	url := "/users/" + userID + "/follows/channels/" + channelID
+	opts := &doOptions{
+		params: map[string]string{
+			"notifications": strconv.FormatBool(notifications),
+		},
+		oauth:   oauth,
+		scope:   "user_follows_edit",
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("PUT", url, opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "FollowChannel")
 	}
 	defer resp.Body.Close()
-	user := &User{}
-	err = json.NewDecoder(resp.Body).Decode(user)
+	follow := &Follow{}
+	err = json.NewDecoder(resp.Body).Decode(follow)
 	if err != nil {
 		return nil, errors.Annotate(err, "Error decoding JSON")
 	}
-	return user, nil
+	return follow, nil
+}
+
+// UnfollowChannel makes the given user stop following the given channel.  Requires an oauth token from the user with
+// the `user_follows_edit` scope.
+func (c *Client) UnfollowChannel(userID string, channelID string, oauth string) error {
+	return c.UnfollowChannelContext(context.Background(), userID, channelID, oauth)
 }
 
-// CheckUserFollowsChannel will return the user object if the user is following the given channel.  If no users and an error is returned, then the user is not following the channel.
-func (c *Client) CheckUserFollowsChannel(userID string, channelID string) (*User, error) {
+// UnfollowChannelContext is like UnfollowChannel but uses the given context for the request.
+func (c *Client) UnfollowChannelContext(ctx context.Context, userID string, channelID string, oauth string) error {
 	url := "/users/" + userID + "/follows/channels/" + channelID
+	opts := &doOptions{
+		oauth:   oauth,
+		scope:   "user_follows_edit",
+		context: ctx,
+	}
 	// Do the request
-	resp, err := c.do("GET", url, &doOptions{})
+	resp, err := c.do("DELETE", url, opts)
 	if err != nil {
-		return nil, errors.Annotate(err, "CheckUserFollowsChannel")
+		return errors.Annotate(err, "UnfollowChannel")
+	}
+	resp.Body.Close()
+	return nil
+}
+
+/*
+GetUserBlockList returns the users blocked by the given user.  Requires an oauth token from the user with the
+`user_blocks_read` scope.
+
+The function takes in four parameters:
+
+	userID:
+		The User ID
+
+	oauth:
+		User oauth token
+
+	limit:
+		The number of blocks to return.  Max is 100, default is 25.
+
+	offset:
+		The offset in the list to return.
+*/
+func (c *Client) GetUserBlockList(userID string, oauth string, limit int, offset int) (*Blocks, error) {
+	return c.GetUserBlockListContext(context.Background(), userID, oauth, limit, offset)
+}
+
+// GetUserBlockListContext is like GetUserBlockList but uses the given context for the request.
+func (c *Client) GetUserBlockListContext(ctx context.Context, userID string, oauth string, limit int, offset int) (*Blocks, error) {
+	limit = pageLimit(limit, 25)
+	url := "/users/" + userID + "/blocks"
+	opts := &doOptions{
+		params: map[string]string{
+			"limit":  strconv.Itoa(limit),
+			"offset": strconv.Itoa(offset),
+		},
+		oauth:   oauth,
+		scope:   "user_blocks_read",
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("GET", url, opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "GetUserBlockList")
 	}
 	defer resp.Body.Close()
-	user := &User{}
-	err = json.NewDecoder(resp.Body).Decode(user)
+	blocks := &Blocks{}
+	err = json.NewDecoder(resp.Body).Decode(blocks)
 	if err != nil {
 		return nil, errors.Annotate(err, "Error decoding JSON")
 	}
-	return user, nil
+	return blocks, nil
+}
+
+// BlockUser makes the given user block the target user and returns the new block.  Requires an oauth token from the
+// user with the `user_blocks_edit` scope.
+func (c *Client) BlockUser(userID string, targetUserID string, oauth string) (*Block, error) {
+	return c.BlockUserContext(context.Background(), userID, targetUserID, oauth)
+}
+
+// BlockUserContext is like BlockUser but uses the given context for the request.
+func (c *Client) BlockUserContext(ctx context.Context, userID string, targetUserID string, oauth string) (*Block, error) {
+	url := "/users/" + userID + "/blocks/" + targetUserID
+	opts := &doOptions{
+		oauth:   oauth,
+		scope:   "user_blocks_edit",
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("PUT", url, opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "BlockUser")
+	}
+	defer resp.Body.Close()
+	block := &Block{}
+	err = json.NewDecoder(resp.Body).Decode(block)
+	if err != nil {
+		return nil, errors.Annotate(err, "Error decoding JSON")
+	}
+	return block, nil
+}
+
+// UnblockUser makes the given user unblock the target user.  If the target user is not blocked an error satisfying
+// IsNotFound is returned.  Requires an oauth token from the user with the `user_blocks_edit` scope.
+func (c *Client) UnblockUser(userID string, targetUserID string, oauth string) error {
+	return c.UnblockUserContext(context.Background(), userID, targetUserID, oauth)
+}
+
+// UnblockUserContext is like UnblockUser but uses the given context for the request.
+func (c *Client) UnblockUserContext(ctx context.Context, userID string, targetUserID string, oauth string) error {
+	url := "/users/" + userID + "/blocks/" + targetUserID
+	opts := &doOptions{
+		oauth:   oauth,
+		scope:   "user_blocks_edit",
+		context: ctx,
+	}
+	// Do the request
+	resp, err := c.do("DELETE", url, opts)
+	if err != nil {
+		return errors.Annotate(err, "UnblockUser")
+	}
+	resp.Body.Close()
+	return nil
 }
@@ -95,3 +95,113 @@ func TestGetUserFollows(t *testing.T) {
 		t.Errorf("GetUserFollows(%q, %q, %q, %q, %q): Expected %#v.  Got %#v.", userID, limit, offset, direction, sortBy, expected, follows)
 	}
 }
+
+func TestFollowChannel(t *testing.T) {
+	jsonResponse := `{
+  "created_at": "2016-12-15T14:55:49Z",
+  "notifications": true,
+  "channel": {
+    "_id": "129454141",
+    "display_name": "DallasTestChannel",
+    "name": "dallastestchannel"
+  }
+}`
+	var expected Follow
+	err := json.Unmarshal([]byte(jsonResponse), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	follow, err := client.FollowChannel("6391593", "129454141", true, "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*follow, expected) {
+		t.Errorf("FollowChannel: Expected %#v.  Got %#v.", expected, *follow)
+	}
+	req := fakeRT.requests[0]
+	if req.Method != "PUT" || req.URL.Path != "/kraken/users/6391593/follows/channels/129454141" || req.URL.Query().Get("notifications") != "true" {
+		t.Errorf("Unexpected request %s %s", req.Method, req.URL)
+	}
+}
+
+func TestUnfollowChannel(t *testing.T) {
+	fakeRT := &FakeRoundTripper{status: http.StatusNoContent}
+	client := newTestClient(fakeRT)
+	err := client.UnfollowChannel("6391593", "129454141", "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := fakeRT.requests[0]
+	if req.Method != "DELETE" || req.URL.Path != "/kraken/users/6391593/follows/channels/129454141" {
+		t.Errorf("Unexpected request %s %s", req.Method, req.URL)
+	}
+}
+
+func TestGetUserBlockList(t *testing.T) {
+	jsonResponse := `{
+  "_total": 1,
+  "blocks": [
+    {
+      "_id": 34105660,
+      "updated_at": "2016-12-15T18:58:11Z",
+      "user": {
+        "_id": "129454141",
+        "bio": null,
+        "created_at": "2016-07-13T14:40:42Z",
+        "display_name": "dallasnchains",
+        "logo": null,
+        "name": "dallasnchains",
+        "type": "user",
+        "updated_at": "2016-12-14T00:32:17Z"
+      }
+    }
+  ]
+}`
+	var expected Blocks
+	err := json.Unmarshal([]byte(jsonResponse), &expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	blocks, err := client.GetUserBlockList("6391593", "fakeoauth", 500, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*blocks, expected) {
+		t.Errorf("GetUserBlockList: Expected %#v.  Got %#v.", expected, *blocks)
+	}
+	if limit := fakeRT.requests[0].URL.Query().Get("limit"); limit != "100" {
+		t.Errorf("Expected limit 100.  Got %s.", limit)
+	}
+}
+
+func TestBlockAndUnblockUser(t *testing.T) {
+	jsonResponse := `{
+  "_id": 34105660,
+  "updated_at": "2016-12-15T18:58:11Z",
+  "user": {
+    "_id": "129454141",
+    "display_name": "dallasnchains",
+    "name": "dallasnchains",
+    "type": "user"
+  }
+}`
+	fakeRT := &FakeRoundTripper{message: jsonResponse, status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	block, err := client.BlockUser("6391593", "129454141", "fakeoauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.ID != "34105660" || block.User.ID != "129454141" {
+		t.Errorf("Unexpected block %#v", *block)
+	}
+	fakeRT = &FakeRoundTripper{message: `{"error":"Not Found","status":404,"message":"Block does not exist"}`, status: http.StatusNotFound}
+	client = newTestClient(fakeRT)
+	err = client.UnblockUser("6391593", "129454141", "fakeoauth")
+	if !IsNotFound(err) {
+		t.Errorf("Expected a not found error.  Got %v.", err)
+	}
+}
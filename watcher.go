@@ -0,0 +1,301 @@
+package twitch2go
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// DefaultWatchInterval is the time between polls of a StreamWatcher that has no Interval set.
+const DefaultWatchInterval = time.Minute
+
+// StreamEventType is the kind of change a StreamWatcher reports.
+type StreamEventType int
+
+const (
+	// WentLive is reported when a watched channel starts streaming.
+	WentLive StreamEventType = iota
+	// WentOffline is reported when a watched channel stops streaming.
+	WentOffline
+	// GameChanged is reported when a live channel switches games.
+	GameChanged
+	// TitleChanged is reported when a live channel changes its status.
+	TitleChanged
+	// ViewerMilestone is reported when a live stream first reaches one of the watcher's Milestones.
+	ViewerMilestone
+)
+
+var streamEventNames = map[StreamEventType]string{
+	WentLive:        "WentLive",
+	WentOffline:     "WentOffline",
+	GameChanged:     "GameChanged",
+	TitleChanged:    "TitleChanged",
+	ViewerMilestone: "ViewerMilestone",
+}
+
+func (t StreamEventType) String() string {
+	if name, ok := streamEventNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("StreamEventType(%d)", int(t))
+}
+
+// StreamEvent is a change in the stream of a watched channel.
+type StreamEvent struct {
+	Type      StreamEventType
+	ChannelID string
+	// Stream is the stream as of the poll that detected the change.  For WentOffline it is the last stream seen.
+	Stream Stream
+	// Previous is the stream as of the previous poll.  It is empty for WentLive.
+	Previous Stream
+	// Milestone is the viewer count reached, for ViewerMilestone.
+	Milestone uint
+	// Time is when the change was detected.
+	Time time.Time
+}
+
+// StreamWatcher polls the streams of a set of channels and reports when they go live, go offline or change.  Channels
+// are fetched in batches, so hundreds of channels only take a few requests per poll.
+//
+//	watcher := twitch2go.NewStreamWatcher(client, channelIDs...)
+//	watcher.Interval = 30 * time.Second
+//	for event := range watcher.Watch(ctx) {
+//		if event.Type == twitch2go.WentLive {
+//			...
+//		}
+//	}
+//
+// The configuration fields must not be changed once the watcher is running.  Add and Remove can be called at any time.
+type StreamWatcher struct {
+	// Interval is the longest time between polls, see Jitter.  Default is DefaultWatchInterval.
+	Interval time.Duration
+	// Jitter is the fraction (0 to 1) by which each Interval is randomly shortened, so that watchers started together do
+	// not poll in lockstep.
+	Jitter float64
+	// BatchSize is the number of channels fetched per request.  Max is 100, default is 100.
+	BatchSize int
+	// Milestones are the viewer counts reported with ViewerMilestone.  Each is reported at most once per stream.
+	Milestones []uint
+	// SkipInitial suppresses WentLive and ViewerMilestone for channels that are already live the first time they are
+	// polled, so only changes after the watcher started are reported.
+	SkipInitial bool
+	// OnError is called with the error of every failed poll.  The watcher keeps polling, and channels whose batch
+	// failed keep their last known state.  Errors are dropped when OnError is nil.
+	OnError func(err error)
+
+	client   *Client
+	mu       sync.Mutex
+	channels []string
+	state    map[string]*watchState
+}
+
+// watchState is what the watcher knows about a channel.  A channel without a watchState has not been polled yet.
+type watchState struct {
+	live   bool
+	stream Stream
+	// milestone is the highest milestone reported for the current stream.
+	milestone uint
+}
+
+// NewStreamWatcher returns a StreamWatcher for the given channels that polls with client.
+func NewStreamWatcher(client *Client, channelIDs ...string) *StreamWatcher {
+	w := &StreamWatcher{
+		client: client,
+		state:  map[string]*watchState{},
+	}
+	w.Add(channelIDs...)
+	return w
+}
+
+// Add starts watching the given channels.  Channels already watched are ignored.
+func (w *StreamWatcher) Add(channelIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range channelIDs {
+		if !w.watching(id) {
+			w.channels = append(w.channels, id)
+		}
+	}
+}
+
+// Remove stops watching the given channels.  No WentOffline is reported for them.
+func (w *StreamWatcher) Remove(channelIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range channelIDs {
+		for i, ch := range w.channels {
+			if ch == id {
+				w.channels = append(w.channels[:i], w.channels[i+1:]...)
+				break
+			}
+		}
+		delete(w.state, id)
+	}
+}
+
+// Channels returns the watched channel IDs.
+func (w *StreamWatcher) Channels() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.channels...)
+}
+
+// watching reports whether the given channel is watched.  Must be called with w.mu held.
+func (w *StreamWatcher) watching(channelID string) bool {
+	for _, ch := range w.channels {
+		if ch == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// Run polls the watched channels every Interval and calls handle with every change, until ctx is done.  The first poll
+// happens immediately.  Failed polls are passed to OnError and do not stop the watcher, so Run only returns ctx.Err().
+func (w *StreamWatcher) Run(ctx context.Context, handle func(StreamEvent)) error {
+	for {
+		events, err := w.Poll(ctx)
+		for _, event := range events {
+			handle(event)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && w.OnError != nil {
+			w.OnError(err)
+		}
+		if err := sleep(ctx, w.wait()); err != nil {
+			return err
+		}
+	}
+}
+
+// Watch runs the watcher in a new goroutine and returns a channel of its events.  The channel is closed once ctx is
+// done, which is the only way Run stops.  Errors are only reported through OnError.  Events are not buffered, so a
+// slow reader delays the next poll.
+func (w *StreamWatcher) Watch(ctx context.Context) <-chan StreamEvent {
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		// Run only returns ctx.Err(), which the reader sees as the channel closing.
+		w.Run(ctx, func(event StreamEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return events
+}
+
+// Poll fetches the watched channels once and returns the changes since the previous poll.  Run calls Poll on every
+// interval; call it directly to drive the watcher from your own loop.  If some batches fail, Poll returns the events of
+// the others together with the first error.  Poll must not be called concurrently with itself or Run.
+func (w *StreamWatcher) Poll(ctx context.Context) ([]StreamEvent, error) {
+	channels := w.Channels()
+	size := pageLimit(w.BatchSize, maxPageSize)
+	var events []StreamEvent
+	var firstErr error
+	for start := 0; start < len(channels); start += size {
+		end := start + size
+		if end > len(channels) {
+			end = len(channels)
+		}
+		batch := channels[start:end]
+		streams, err := w.client.getStreams(ctx, batch, StreamsOptions{})
+		if err != nil {
+			if firstErr == nil {
+				firstErr = errors.Annotate(err, "StreamWatcher")
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		events = append(events, w.diff(batch, streams, time.Now())...)
+	}
+	return events, firstErr
+}
+
+// diff records the streams fetched for a batch of channels and returns the changes.
+func (w *StreamWatcher) diff(batch []string, streams []Stream, now time.Time) []StreamEvent {
+	live := make(map[string]Stream, len(streams))
+	for _, s := range streams {
+		live[s.Channel.ID.String()] = s
+	}
+	milestones := append([]uint(nil), w.Milestones...)
+	sort.Slice(milestones, func(i, j int) bool { return milestones[i] < milestones[j] })
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var events []StreamEvent
+	for _, id := range batch {
+		if !w.watching(id) {
+			// Removed while the batch was fetched.
+			continue
+		}
+		cur, isLive := live[id]
+		st, polled := w.state[id]
+		if !polled {
+			st = &watchState{}
+			w.state[id] = st
+		}
+		if st.live && (!isLive || cur.ID != st.stream.ID) {
+			// A different stream ID means the channel restarted its stream between two polls.
+			events = append(events, StreamEvent{Type: WentOffline, ChannelID: id, Stream: st.stream, Previous: st.stream, Time: now})
+			st.live = false
+			st.milestone = 0
+		}
+		if !isLive {
+			continue
+		}
+		if !st.live {
+			st.live, st.stream = true, cur
+			if !polled && w.SkipInitial {
+				st.milestone = reachedMilestone(milestones, cur.Viewers)
+				continue
+			}
+			events = append(events, StreamEvent{Type: WentLive, ChannelID: id, Stream: cur, Time: now})
+		} else {
+			prev := st.stream
+			st.stream = cur
+			if cur.Game != prev.Game {
+				events = append(events, StreamEvent{Type: GameChanged, ChannelID: id, Stream: cur, Previous: prev, Time: now})
+			}
+			if cur.Channel.Status != prev.Channel.Status {
+				events = append(events, StreamEvent{Type: TitleChanged, ChannelID: id, Stream: cur, Previous: prev, Time: now})
+			}
+		}
+		for _, m := range milestones {
+			if m > st.milestone && m <= cur.Viewers {
+				events = append(events, StreamEvent{Type: ViewerMilestone, ChannelID: id, Stream: cur, Milestone: m, Time: now})
+				st.milestone = m
+			}
+		}
+	}
+	return events
+}
+
+// reachedMilestone returns the highest of the sorted milestones that viewers has reached, or 0.
+func reachedMilestone(milestones []uint, viewers uint) uint {
+	reached := uint(0)
+	for _, m := range milestones {
+		if m <= viewers {
+			reached = m
+		}
+	}
+	return reached
+}
+
+// wait returns the time until the next poll.
+func (w *StreamWatcher) wait() time.Duration {
+	d := w.Interval
+	if d <= 0 {
+		d = DefaultWatchInterval
+	}
+	return jitter(d, w.Jitter)
+}
@@ -0,0 +1,148 @@
+package twitch2go
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func streamJSON(streams ...string) string {
+	return `{"_total": ` + strconv.Itoa(len(streams)) + `, "streams": [` + strings.Join(streams, ",") + `]}`
+}
+
+func watchedStream(id string, channelID string, game string, status string, viewers string) string {
+	return `{"_id": ` + id + `, "game": "` + game + `", "viewers": ` + viewers + `, "channel": {"_id": ` + channelID + `, "status": "` + status + `"}}`
+}
+
+func eventTypes(events []StreamEvent) []StreamEventType {
+	types := []StreamEventType{}
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestStreamWatcherPoll(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: streamJSON(), status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	watcher := NewStreamWatcher(client, "1", "2")
+	watcher.Milestones = []uint{1000, 100}
+	polls := []struct {
+		message  string
+		expected []StreamEventType
+	}{
+		{streamJSON(), []StreamEventType{}},
+		{streamJSON(watchedStream("10", "1", "Overwatch", "hi", "50")), []StreamEventType{WentLive}},
+		{streamJSON(watchedStream("10", "1", "Overwatch", "hi", "150")), []StreamEventType{ViewerMilestone}},
+		{streamJSON(watchedStream("10", "1", "Dota 2", "bye", "120")), []StreamEventType{GameChanged, TitleChanged}},
+		{streamJSON(watchedStream("10", "1", "Dota 2", "bye", "160")), []StreamEventType{}},
+		{streamJSON(watchedStream("11", "1", "Dota 2", "bye", "1500")), []StreamEventType{WentOffline, WentLive, ViewerMilestone, ViewerMilestone}},
+		{streamJSON(), []StreamEventType{WentOffline}},
+	}
+	for i, poll := range polls {
+		fakeRT.message = poll.message
+		events, err := watcher.Poll(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := eventTypes(events); !reflect.DeepEqual(got, poll.expected) {
+			t.Errorf("poll %d: Expected %v.  Got %v.", i, poll.expected, got)
+		}
+	}
+	if channel := fakeRT.requests[0].URL.Query().Get("channel"); channel != "1,2" {
+		t.Errorf("Expected %#v.  Got %#v.", "1,2", channel)
+	}
+}
+
+func TestStreamWatcherBatches(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: streamJSON(), status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	watcher := NewStreamWatcher(client, "1", "2", "3", "4", "5")
+	watcher.BatchSize = 2
+	if _, err := watcher.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var batches []string
+	for _, req := range fakeRT.requests {
+		batches = append(batches, req.URL.Query().Get("channel"))
+	}
+	expected := []string{"1,2", "3,4", "5"}
+	if !reflect.DeepEqual(batches, expected) {
+		t.Errorf("Expected %#v.  Got %#v.", expected, batches)
+	}
+}
+
+func TestStreamWatcherSkipInitial(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: streamJSON(watchedStream("10", "1", "Overwatch", "hi", "500")), status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	watcher := NewStreamWatcher(client, "1")
+	watcher.SkipInitial = true
+	watcher.Milestones = []uint{100, 1000}
+	events, err := watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events for a channel already live.  Got %v.", eventTypes(events))
+	}
+	fakeRT.message = streamJSON(watchedStream("10", "1", "Overwatch", "hi", "1000"))
+	events, err = watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Type != ViewerMilestone || events[0].Milestone != 1000 {
+		t.Errorf("Expected only the 1000 viewer milestone.  Got %#v.", events)
+	}
+}
+
+func TestStreamWatcherKeepsStateOnError(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: streamJSON(watchedStream("10", "1", "Overwatch", "hi", "5")), status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	client.RetryPolicy = nil
+	watcher := NewStreamWatcher(client, "1")
+	if _, err := watcher.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	fakeRT.status = http.StatusInternalServerError
+	events, err := watcher.Poll(context.Background())
+	if err == nil || len(events) != 0 {
+		t.Fatalf("Expected an error and no events.  Got %v and %v.", err, eventTypes(events))
+	}
+	fakeRT.status = http.StatusOK
+	events, err = watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected the failed poll not to change state.  Got %v.", eventTypes(events))
+	}
+}
+
+func TestStreamWatcherRunStops(t *testing.T) {
+	fakeRT := &FakeRoundTripper{message: streamJSON(), status: http.StatusOK}
+	client := newTestClient(fakeRT)
+	watcher := NewStreamWatcher(client, "1")
+	watcher.Interval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := watcher.Run(ctx, func(StreamEvent) {})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected %v.  Got %v.", context.DeadlineExceeded, err)
+	}
+	if len(fakeRT.requests) < 2 {
+		t.Errorf("Expected several polls.  Got %d.", len(fakeRT.requests))
+	}
+}
+
+func TestStreamWatcherJitter(t *testing.T) {
+	watcher := &StreamWatcher{Interval: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if d := watcher.wait(); d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("Expected a wait between 500ms and 1s.  Got %s.", d)
+		}
+	}
+}